@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
+	"github.com/gyurisc/adocli/internal/config"
 	"github.com/spf13/cobra"
 	"github.com/zalando/go-keyring"
 )
@@ -16,13 +18,92 @@ const (
 	keyringUser    = "pat"
 )
 
-// GetPAT retrieves the stored PAT from the OS keyring.
+// keyringAccount returns the keyring user for the organization in use, so each
+// profile keeps its own PAT. Without a profile the plain "pat" user is used.
+// When --org or ADO_ORGANIZATION points away from the active profile, the PAT
+// of the profile for that organization is used, or the plain user if none.
+func keyringAccount() string {
+	cfg, err := config.Load()
+	if err != nil {
+		return keyringUser
+	}
+	active, ok := cfg.Active()
+	if !ok {
+		return keyringUser
+	}
+	org := defaultOrganization()
+	if strings.EqualFold(active.Organization, org) {
+		return keyringUser + ":" + cfg.ActiveProfile
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Profiles)) {
+		if strings.EqualFold(cfg.Profiles[name].Organization, org) {
+			return keyringUser + ":" + name
+		}
+	}
+	return keyringUser
+}
+
+// patEnvVars are checked, in order, before the keyring.
+var patEnvVars = []string{"ADO_PAT", "AZURE_DEVOPS_EXT_PAT"}
+
+// PAT sources reported by 'auth status'.
+const (
+	sourceKeyring   = "keyring"
+	sourceFileStore = "credentials file"
+)
+
+// GetPAT returns the PAT to use. The ADO_PAT and AZURE_DEVOPS_EXT_PAT
+// environment variables take precedence over the active profile's keyring
+// entry, which in turn takes precedence over the credentials file.
 func GetPAT() (string, error) {
-	pat, err := keyring.Get(keyringService, keyringUser)
+	pat, _, err := lookupPAT()
+	return pat, err
+}
+
+// lookupPAT returns the PAT and a description of where it came from.
+func lookupPAT() (pat, source string, err error) {
+	for _, name := range patEnvVars {
+		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+			return v, name + " environment variable", nil
+		}
+	}
+	pat, keyringErr := keyring.Get(keyringService, keyringAccount())
+	if keyringErr == nil && pat != "" {
+		return pat, sourceKeyring, nil
+	}
+	pat, err = config.GetCredential(keyringAccount())
 	if err != nil {
-		return "", fmt.Errorf("no PAT found in keyring (run 'ado auth login'): %w", err)
+		return "", "", err
+	}
+	if pat != "" {
+		return pat, sourceFileStore, nil
+	}
+	return "", "", fmt.Errorf("no PAT found in keyring (run 'ado auth login' or set ADO_PAT): %w", keyringErr)
+}
+
+// storePAT saves the PAT in the keyring, or in the credentials file when
+// fileStore is set or the keyring is unavailable. It returns the store used.
+func storePAT(pat string, fileStore bool) (string, error) {
+	if !fileStore {
+		err := keyring.Set(keyringService, keyringAccount(), pat)
+		if err == nil {
+			return sourceKeyring, nil
+		}
+		fmt.Fprintf(os.Stderr, "Warning: keyring unavailable (%v); falling back to the credentials file.\n", err)
+	}
+	if err := config.SetCredential(keyringAccount(), pat); err != nil {
+		return "", fmt.Errorf("storing PAT in credentials file: %w", err)
 	}
-	return pat, nil
+	return sourceFileStore, nil
+}
+
+// deletePAT removes the PAT from the given store.
+func deletePAT(source string) error {
+	if source == sourceFileStore {
+		_, err := config.DeleteCredential(keyringAccount())
+		return err
+	}
+	return keyring.Delete(keyringService, keyringAccount())
 }
 
 var authCmd = &cobra.Command{
@@ -42,14 +123,23 @@ var authLoginCmd = &cobra.Command{
 
 Provide the token via --pat flag or enter it interactively:
   ado auth login --pat <token>
-  ado auth login`,
+  ado auth login
+
+The token is checked against the configured organization before login
+succeeds. Use --no-verify to store it without a network call.
+
+If no OS keyring is available (e.g. a headless Linux server), or --file-store
+is given, the token is written unencrypted to ~/.config/ado/credentials with
+owner-only (0600) permissions.`,
 	RunE: runAuthLogin,
 }
 
 func runAuthLogin(cmd *cobra.Command, args []string) error {
 	pat := patFlag
 	if pat == "" {
-		fmt.Fprint(os.Stderr, "Enter PAT: ")
+		if isTTY(os.Stdin) {
+			fmt.Fprint(os.Stderr, "Enter PAT: ")
+		}
 		reader := bufio.NewReader(os.Stdin)
 		line, err := reader.ReadString('\n')
 		if err != nil {
@@ -61,11 +151,34 @@ func runAuthLogin(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("PAT cannot be empty")
 	}
 
-	if err := keyring.Set(keyringService, keyringUser, pat); err != nil {
-		return fmt.Errorf("storing PAT in keyring: %w", err)
+	noVerify, _ := cmd.Flags().GetBool("no-verify")
+	fileStore, _ := cmd.Flags().GetBool("file-store")
+
+	org := defaultOrganization()
+	if noVerify || org == "" {
+		store, err := storePAT(pat, fileStore)
+		if err != nil {
+			return err
+		}
+		if noVerify {
+			fmt.Fprintf(os.Stderr, "PAT stored successfully in %s.\n", store)
+		} else {
+			fmt.Fprintf(os.Stderr, "PAT stored in %s, but not verified: organization not configured (run 'ado config set organization <org>').\n", store)
+		}
+		return nil
 	}
 
-	fmt.Fprintln(os.Stderr, "PAT stored successfully.")
+	// Verify before storing, so a mistyped token never replaces a working one.
+
+	conn, err := newClientWithPAT(org, pat).GetConnectionData(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("verifying PAT against organization %q (token not stored): %w", org, err)
+	}
+	store, err := storePAT(pat, fileStore)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "PAT stored successfully in %s. Logged in to %s as %s.\n", store, org, conn.AuthenticatedUser.DisplayName)
 	return nil
 }
 
@@ -74,15 +187,25 @@ func runAuthLogin(cmd *cobra.Command, args []string) error {
 var authLogoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Remove stored credentials",
-	Long:  "Delete the stored PAT from the OS keyring.",
+	Long:  "Delete the stored PAT from the OS keyring and the credentials file.",
 	RunE:  runAuthLogout,
 }
 
 func runAuthLogout(cmd *cobra.Command, args []string) error {
-	if err := keyring.Delete(keyringService, keyringUser); err != nil {
-		return fmt.Errorf("removing PAT from keyring: %w", err)
+	keyringErr := deletePAT(sourceKeyring)
+	fileRemoved, err := config.DeleteCredential(keyringAccount())
+	if err != nil {
+		return fmt.Errorf("removing PAT from credentials file: %w", err)
+	}
+
+	switch {
+	case keyringErr == nil:
+		fmt.Fprintln(os.Stderr, "PAT removed from keyring.")
+	case fileRemoved:
+		fmt.Fprintln(os.Stderr, "PAT removed from credentials file.")
+	default:
+		return fmt.Errorf("removing PAT from keyring: %w", keyringErr)
 	}
-	fmt.Fprintln(os.Stderr, "PAT removed from keyring.")
 	return nil
 }
 
@@ -91,46 +214,90 @@ func runAuthLogout(cmd *cobra.Command, args []string) error {
 var authStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show authentication status",
-	Long:  "Check whether a PAT is stored in the OS keyring.",
-	RunE:  runAuthStatus,
+	Long: `Check whether a PAT is available from the environment, the OS keyring, or the
+credentials file, and show the configured organization.
+
+With --online the token is also checked against the organization and the
+signed-in user is shown.`,
+	RunE: runAuthStatus,
 }
 
 type authStatusOutput struct {
 	Authenticated bool   `json:"authenticated"`
 	TokenStored   bool   `json:"token_stored"`
 	TokenPrefix   string `json:"token_prefix,omitempty"`
+	Source        string `json:"source,omitempty"`
+	Organization  string `json:"organization,omitempty"`
+	User          string `json:"user,omitempty"`
+	UniqueName    string `json:"unique_name,omitempty"`
+	Error         string `json:"error,omitempty"`
 }
 
 func runAuthStatus(cmd *cobra.Command, args []string) error {
-	pat, err := keyring.Get(keyringService, keyringUser)
+	online, _ := cmd.Flags().GetBool("online")
+
+	pat, source, err := lookupPAT()
 	authenticated := err == nil && pat != ""
 
 	status := authStatusOutput{
 		Authenticated: authenticated,
-		TokenStored:   authenticated,
+		TokenStored:   authenticated && (source == sourceKeyring || source == sourceFileStore),
+		Organization:  defaultOrganization(),
+	}
+	if authenticated {
+		status.Source = source
 	}
 	if authenticated && len(pat) >= 4 {
 		status.TokenPrefix = pat[:4] + "..."
 	}
 
+	// Only hit the network when asked, so plain status stays fast.
+	if authenticated && online {
+		if status.Organization == "" {
+			status.Error = "organization not configured"
+		} else {
+			conn, err := newClientWithPAT(status.Organization, pat).GetConnectionData(cmd.Context())
+			if err != nil {
+				status.Authenticated = false
+				status.Error = err.Error()
+			} else {
+				status.User = conn.AuthenticatedUser.DisplayName
+				status.UniqueName = conn.AuthenticatedUser.UniqueName
+			}
+		}
+	}
+
 	switch OutputFormat() {
 	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(status)
+		return printJSON(status)
 	default:
-		if authenticated {
-			fmt.Printf("Authenticated: yes (token: %s)\n", status.TokenPrefix)
+		if status.Authenticated {
+			fmt.Printf("Authenticated: yes (token: %s, from %s)\n", status.TokenPrefix, status.Source)
+		} else if authenticated {
+			fmt.Printf("Authenticated: no (token: %s, from %s)\n", status.TokenPrefix, status.Source)
 		} else {
 			fmt.Println("Authenticated: no")
 			fmt.Println("Run 'ado auth login' to authenticate.")
 		}
+		if status.Organization != "" {
+			fmt.Printf("Organization:  %s\n", status.Organization)
+		}
+		if status.User != "" {
+			fmt.Printf("User:          %s (%s)\n", status.User, status.UniqueName)
+		}
+		if status.Error != "" {
+			fmt.Printf("Error:         %s\n", status.Error)
+		}
 		return nil
 	}
 }
 
 func init() {
 	authLoginCmd.Flags().StringVar(&patFlag, "pat", "", "Personal Access Token (non-interactive)")
+	authLoginCmd.Flags().Bool("no-verify", false, "Store the token without checking it against the organization")
+	authLoginCmd.Flags().Bool("file-store", false, "Store the token unencrypted (mode 0600) in ~/.config/ado/credentials instead of the OS keyring")
+
+	authStatusCmd.Flags().Bool("online", false, "Verify the token with Azure DevOps and show the signed-in user")
 
 	authCmd.AddCommand(authLoginCmd)
 	authCmd.AddCommand(authLogoutCmd)
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gyurisc/adocli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var branchCmd = &cobra.Command{
+	Use:     "branch",
+	Aliases: []string{"branches"},
+	Short:   "Manage Git branches",
+	Long:    "List branches of Azure DevOps Git repositories.",
+}
+
+// --- ado branch list ---
+
+var branchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List branches",
+	Long: `List the branches of a repository with their head commit and that commit's
+author.
+
+  ado branch list --repo Web --filter feature/`,
+	RunE: runBranchList,
+}
+
+func runBranchList(cmd *cobra.Command, args []string) error {
+	repo := resolveRepo(cmd)
+	filter, _ := cmd.Flags().GetString("filter")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	if repo == "" {
+		if repo, err = originRepoName(); err != nil {
+			return fmt.Errorf("--repo not given and could not be detected: %w", err)
+		}
+	}
+	repoID, err := resolveRepoID(ctx, client, project, repo)
+	if err != nil {
+		return err
+	}
+
+	refs, err := client.ListBranches(ctx, project, repoID, filter)
+	if err != nil {
+		return fmt.Errorf("listing branches: %w", err)
+	}
+	branches, err := withHeadAuthors(ctx, client, project, repoID, refs)
+	if err != nil {
+		return err
+	}
+
+	if len(branches) == 0 {
+		if OutputFormat() == "json" {
+			return printJSON([]interface{}{})
+		} else {
+			fmt.Fprintln(os.Stderr, "No branches found.")
+		}
+		return nil
+	}
+
+	defer startPager()()
+
+	if ok, err := printTemplate(branches); ok {
+		return err
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(branches)
+	case "plain":
+		for _, b := range branches {
+			fmt.Printf("%s\t%s\t%s\n", shortBranch(b.Name), b.ObjectID, b.authorName())
+		}
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{"Name", "Commit", "Author"})
+		for _, b := range branches {
+			_ = w.Write([]string{shortBranch(b.Name), b.ObjectID, b.authorName()})
+		}
+		w.Flush()
+		return w.Error()
+	default: // table
+		fmt.Fprintf(os.Stdout, "%-50s %-10s %s\n", "Name", "Commit", "Author")
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", 90))
+		for _, b := range branches {
+			fmt.Fprintf(os.Stdout, "%-50s %-10s %s\n",
+				truncate(shortBranch(b.Name), 50), shortCommit(b.ObjectID), b.authorName())
+		}
+	}
+	return nil
+}
+
+// branchInfo is a branch together with the author of its head commit.
+type branchInfo struct {
+	api.GitRef
+	Author *api.GitUserDate `json:"author,omitempty"`
+}
+
+// authorName returns the head commit's author, or "" if it is unknown.
+func (b branchInfo) authorName() string {
+	if b.Author == nil {
+		return ""
+	}
+	return b.Author.Name
+}
+
+// withHeadAuthors looks up the head commit of every branch in one batched
+// request and attaches its author.
+func withHeadAuthors(ctx context.Context, client *api.Client, project, repoID string, refs []api.GitRef) ([]branchInfo, error) {
+	ids := make([]string, 0, len(refs))
+	for _, r := range refs {
+		ids = append(ids, r.ObjectID)
+	}
+	commits, err := client.GetCommits(ctx, project, repoID, slices.Compact(slices.Sorted(slices.Values(ids))))
+	if err != nil {
+		return nil, fmt.Errorf("fetching head commits: %w", err)
+	}
+	authors := make(map[string]*api.GitUserDate, len(commits))
+	for _, c := range commits {
+		authors[c.CommitID] = c.Author
+	}
+
+	branches := make([]branchInfo, 0, len(refs))
+	for _, r := range refs {
+		branches = append(branches, branchInfo{GitRef: r, Author: authors[r.ObjectID]})
+	}
+	return branches, nil
+}
+
+// shortCommit abbreviates a commit ID to the usual 8 characters.
+func shortCommit(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+func init() {
+	// List flags
+	addProjectFlags(branchListCmd)
+	supportsTemplate(branchListCmd)
+	branchListCmd.Flags().String("repo", "", "Repository name (default: config repo, else from the origin remote)")
+	_ = branchListCmd.RegisterFlagCompletionFunc("repo", completeRepos)
+	branchListCmd.Flags().String("filter", "", "Only branches whose name starts with this prefix")
+
+	branchCmd.AddCommand(branchListCmd)
+
+	rootCmd.AddCommand(branchCmd)
+}
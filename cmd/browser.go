@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// openBrowser opens url in the user's web browser. $BROWSER takes precedence
+// over the platform default.
+func openBrowser(url string) error {
+	var c *exec.Cmd
+	switch {
+	case os.Getenv("BROWSER") != "":
+		c = exec.Command(os.Getenv("BROWSER"), url)
+	case runtime.GOOS == "darwin":
+		c = exec.Command("open", url)
+	case runtime.GOOS == "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		c = exec.Command("xdg-open", url)
+	}
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("opening browser: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gyurisc/adocli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var buildCmd = &cobra.Command{
+	Use:     "build",
+	Aliases: []string{"builds"},
+	Short:   "Inspect builds",
+	Long:    "Inspect Azure Pipelines builds and their logs.",
+}
+
+// --- ado build logs ---
+
+var buildLogsCmd = &cobra.Command{
+	Use:   "logs <buildId>",
+	Short: "Print the logs of a build",
+	Long: `Print the logs of a build to stdout, concatenated in log ID order.
+
+  ado build logs 1234
+  ado build logs 1234 --log-id 7
+  ado build logs 1234 --follow`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBuildLogs,
+}
+
+// buildPollInterval is how often --follow checks for new log lines.
+const buildPollInterval = 5 * time.Second
+
+func runBuildLogs(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid build ID: %s", args[0])
+	}
+	logID, _ := cmd.Flags().GetInt("log-id")
+	follow, _ := cmd.Flags().GetBool("follow")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	// printed tracks how many lines of each log have been written so far.
+	printed := map[int]int{}
+	if !follow {
+		return printBuildLogs(ctx, client, project, id, logID, printed)
+	}
+
+	for {
+		// Check the status first so the final pass sees every line.
+		build, err := client.GetBuild(ctx, project, id)
+		if err != nil {
+			return fmt.Errorf("fetching build %d: %w", id, err)
+		}
+		if err := printBuildLogs(ctx, client, project, id, logID, printed); err != nil {
+			return err
+		}
+		if build.Status == "completed" {
+			return nil
+		}
+		if err := sleepCtx(ctx, buildPollInterval); err != nil {
+			return fmt.Errorf("following build %d: %w", id, err)
+		}
+	}
+}
+
+// printBuildLogs writes the lines of a build's logs (or only logID, if
+// non-zero) that have not been printed yet, updating printed.
+func printBuildLogs(ctx context.Context, client *api.Client, project string, buildID, logID int, printed map[int]int) error {
+	logs, err := client.GetBuildLogs(ctx, project, buildID)
+	if err != nil {
+		return fmt.Errorf("listing logs for build %d: %w", buildID, err)
+	}
+
+	found := false
+	for _, l := range logs {
+		if logID != 0 && l.ID != logID {
+			continue
+		}
+		found = true
+		if l.LineCount <= printed[l.ID] {
+			continue
+		}
+		lines, err := client.GetBuildLogLines(ctx, project, buildID, l.ID)
+		if err != nil {
+			return fmt.Errorf("fetching log %d of build %d: %w", l.ID, buildID, err)
+		}
+		for _, line := range lines[min(printed[l.ID], len(lines)):] {
+			fmt.Println(line)
+		}
+		printed[l.ID] = len(lines)
+	}
+	// Logs appear as jobs start, so a missing log is only an error once the
+	// build has produced some.
+	if logID != 0 && !found && len(logs) > 0 {
+		return fmt.Errorf("build %d has no log %d", buildID, logID)
+	}
+	return nil
+}
+
+func init() {
+	// Logs flags
+	addProjectFlags(buildLogsCmd)
+	buildLogsCmd.Flags().Int("log-id", 0, "Print only this log")
+	buildLogsCmd.Flags().BoolP("follow", "f", false, "Keep printing new lines until the build completes")
+
+	buildCmd.AddCommand(buildLogsCmd)
+
+	rootCmd.AddCommand(buildCmd)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+)
+
+// ANSI color codes used in table output.
+const (
+	colorReset   = "\x1b[0m"
+	colorRed     = "\x1b[31m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorBlue    = "\x1b[34m"
+	colorMagenta = "\x1b[35m"
+	colorGray    = "\x1b[90m"
+)
+
+// stateColors maps work item states and PR statuses (lowercased) to colors.
+var stateColors = map[string]string{
+	// Work item states
+	"new":         colorBlue,
+	"to do":       colorBlue,
+	"proposed":    colorBlue,
+	"active":      colorGreen,
+	"in progress": colorGreen,
+	"committed":   colorGreen,
+	"doing":       colorGreen,
+	"resolved":    colorYellow,
+	"closed":      colorGray,
+	"done":        colorGray,
+	"removed":     colorGray,
+
+	// Pull request statuses ("active" is shared with work items)
+	"completed": colorMagenta,
+	"abandoned": colorRed,
+}
+
+// colorEnabled reports whether output may use ANSI colors: only for table
+// output to a terminal, and never when NO_COLOR is set.
+func colorEnabled() bool {
+	return !noColor() && OutputFormat() == "table" && (pagerActive || isTTY(os.Stdout))
+}
+
+// colorize wraps s in the given color when colors are enabled.
+func colorize(s, color string) string {
+	if color == "" || !colorEnabled() {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// colorState colors an already padded table cell by its work item state or PR
+// status. Padding first keeps the column widths intact.
+func colorState(cell, state string) string {
+	return colorize(cell, stateColors[strings.ToLower(state)])
+}
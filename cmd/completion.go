@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// --- ado completion ---
+
+var completionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh|fish|powershell>",
+	Short: "Generate a shell completion script",
+	Long: `Generate a completion script for your shell. Project and repository names
+are completed live from Azure DevOps.
+
+  bash:        source <(ado completion bash)
+  zsh:         ado completion zsh > "${fpath[1]}/_ado"
+  fish:        ado completion fish > ~/.config/fish/completions/ado.fish
+  powershell:  ado completion powershell | Out-String | Invoke-Expression`,
+	Args:                  cobra.ExactArgs(1),
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	DisableFlagsInUseLine: true,
+	RunE:                  runCompletion,
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	switch args[0] {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(os.Stdout, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(os.Stdout)
+	case "fish":
+		return rootCmd.GenFishCompletion(os.Stdout, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+	default:
+		return cmd.Help()
+	}
+}
+
+// --- helpers ---
+
+// completeProjects completes --project with the organization's project names.
+func completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := newAPIClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	projects, err := client.ListProjects(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := make([]string, 0, len(projects))
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRepos completes --repo with the repository names in the project.
+func completeRepos(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := newAPIClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	repos, err := client.ListRepositories(cmd.Context(), project)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := make([]string, 0, len(repos))
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
@@ -1,14 +1,22 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/gyurisc/adocli/internal/config"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
+// configKeys lists the valid keys for 'config get' and 'config set'.
+const configKeys = "organization, project, repo, output_format, auto_label_cli_prs, api_version"
+
 var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "Manage CLI configuration",
@@ -21,9 +29,12 @@ var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
 	Short: "Set a configuration value",
 	Long: `Set a configuration value. Valid keys:
-  organization   Azure DevOps organization name
-  project        Default project name
-  output_format  Default output format (table, json, plain)`,
+  organization        Azure DevOps organization name
+  project             Default project name or ID
+  repo                Default repository for pr commands
+  output_format       Default output format (table, json, plain, csv)
+  auto_label_cli_prs  Label pull requests created by ado (true, false)
+  api_version         REST API version, e.g. 7.1 (also ADO_API_VERSION)`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConfigSet,
 }
@@ -41,13 +52,23 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		cfg.Organization = value
 	case "project":
 		cfg.Project = value
+	case "repo":
+		cfg.Repo = value
 	case "output_format":
-		if value != "table" && value != "json" && value != "plain" {
-			return fmt.Errorf("invalid output_format %q (must be table, json, or plain)", value)
+		if value != "table" && value != "json" && value != "plain" && value != "csv" {
+			return fmt.Errorf("invalid output_format %q (must be table, json, plain, or csv)", value)
 		}
 		cfg.OutputFormat = value
+	case "auto_label_cli_prs":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid auto_label_cli_prs %q (must be true or false)", value)
+		}
+		cfg.AutoLabelCLIPRs = b
+	case "api_version":
+		cfg.APIVersion = value
 	default:
-		return fmt.Errorf("unknown config key %q (valid: organization, project, output_format)", key)
+		return fmt.Errorf("unknown config key %q (valid: %s)", key, configKeys)
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -80,10 +101,16 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		value = cfg.Organization
 	case "project":
 		value = cfg.Project
+	case "repo":
+		value = cfg.Repo
 	case "output_format":
 		value = cfg.OutputFormat
+	case "auto_label_cli_prs":
+		value = strconv.FormatBool(cfg.AutoLabelCLIPRs)
+	case "api_version":
+		value = cfg.APIVersion
 	default:
-		return fmt.Errorf("unknown config key %q (valid: organization, project, output_format)", key)
+		return fmt.Errorf("unknown config key %q (valid: %s)", key, configKeys)
 	}
 
 	fmt.Println(value)
@@ -106,13 +133,15 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 
 	switch OutputFormat() {
 	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(cfg)
+		return printJSON(cfg)
 	default:
-		fmt.Printf("organization  = %s\n", cfg.Organization)
-		fmt.Printf("project       = %s\n", cfg.Project)
-		fmt.Printf("output_format = %s\n", cfg.OutputFormat)
+		fmt.Printf("organization       = %s\n", cfg.Organization)
+		fmt.Printf("project            = %s\n", cfg.Project)
+		fmt.Printf("repo               = %s\n", cfg.Repo)
+		fmt.Printf("output_format      = %s\n", cfg.OutputFormat)
+		fmt.Printf("auto_label_cli_prs = %t\n", cfg.AutoLabelCLIPRs)
+		fmt.Printf("api_version        = %s\n", cfg.APIVersion)
+		fmt.Printf("active_profile     = %s\n", cfg.ActiveProfile)
 		path, err := config.Path()
 		if err == nil {
 			fmt.Printf("\nConfig file: %s\n", path)
@@ -121,10 +150,274 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// --- ado config edit ---
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the config file in $EDITOR",
+	Long: `Open the config file in $EDITOR (or vi, or notepad on Windows). When the
+editor exits the file is validated; if it is invalid the previous contents are
+restored.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigEdit,
+}
+
+func runConfigEdit(cmd *cobra.Command, args []string) error {
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+
+	// Make sure there is a file to edit, and remember it for rollback.
+	previous, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		cfg, loadErr := config.Load()
+		if loadErr != nil {
+			return loadErr
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("saving config: %w", err)
+		}
+		previous, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+		if runtime.GOOS == "windows" {
+			editor = []string{"notepad"}
+		}
+	}
+	c := exec.Command(editor[0], append(editor[1:], path)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("running editor: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		if restoreErr := os.WriteFile(path, previous, 0644); restoreErr != nil {
+			return fmt.Errorf("%w (restoring previous config also failed: %v)", err, restoreErr)
+		}
+		return fmt.Errorf("%w; previous config restored", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Saved %s\n", path)
+	return nil
+}
+
+// --- ado config profile ---
+
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage organization profiles",
+	Long: `Manage named profiles for working with several organizations.
+
+While a profile is active its organization and project replace the top-level
+config values (ADO_ORGANIZATION, ADO_PROJECT, and --org still override them),
+and its PAT is stored separately in the keyring:
+  ado config profile add work --organization contoso --project Web
+  ado config profile use work
+  ado auth login`,
+}
+
+var configProfileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or replace a profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigProfileAdd,
+}
+
+func runConfigProfileAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	org, _ := cmd.Flags().GetString("organization")
+	project, _ := cmd.Flags().GetString("project")
+	if org == "" {
+		return fmt.Errorf("--organization is required")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]config.Profile{}
+	}
+	cfg.Profiles[name] = config.Profile{Organization: org, Project: project}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Added profile %q (run 'ado config profile use %s' to switch to it)\n", name, name)
+	return nil
+}
+
+var configProfileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Switch to a profile",
+	Long: `Make a profile active. Use --none to go back to the top-level
+organization and project settings.`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: runConfigProfileUse,
+}
+
+func runConfigProfileUse(cmd *cobra.Command, args []string) error {
+	none, _ := cmd.Flags().GetBool("none")
+	if none == (len(args) == 1) {
+		return fmt.Errorf("specify a profile name or --none")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if none {
+		cfg.ActiveProfile = ""
+	} else {
+		if _, ok := cfg.Profiles[args[0]]; !ok {
+			return fmt.Errorf("unknown profile %q (see 'ado config profile list')", args[0])
+		}
+		cfg.ActiveProfile = args[0]
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	if none {
+		fmt.Fprintln(os.Stderr, "No profile active.")
+	} else {
+		fmt.Fprintf(os.Stderr, "Using profile %q.\n", cfg.ActiveProfile)
+	}
+	return nil
+}
+
+var configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	RunE:  runConfigProfileList,
+}
+
+type profileOutput struct {
+	Name         string `json:"name"`
+	Organization string `json:"organization"`
+	Project      string `json:"project,omitempty"`
+	Active       bool   `json:"active"`
+}
+
+func runConfigProfileList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	profiles := make([]profileOutput, 0, len(names))
+	for _, name := range names {
+		p := cfg.Profiles[name]
+		profiles = append(profiles, profileOutput{
+			Name:         name,
+			Organization: p.Organization,
+			Project:      p.Project,
+			Active:       name == cfg.ActiveProfile,
+		})
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(profiles)
+	case "plain":
+		for _, p := range profiles {
+			fmt.Printf("%s\t%s\t%s\t%t\n", p.Name, p.Organization, p.Project, p.Active)
+		}
+	default:
+		if len(profiles) == 0 {
+			fmt.Println("No profiles configured.")
+			return nil
+		}
+		fmt.Printf("  %-20s %-30s %s\n", "Name", "Organization", "Project")
+		fmt.Println(strings.Repeat("-", 80))
+		for _, p := range profiles {
+			marker := " "
+			if p.Active {
+				marker = "*"
+			}
+			fmt.Printf("%s %-20s %-30s %s\n", marker, p.Name, p.Organization, p.Project)
+		}
+	}
+	return nil
+}
+
+// --- helpers ---
+
+// activeProfile returns the active profile and its name, if one is set.
+func activeProfile() (string, config.Profile, bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", config.Profile{}, false
+	}
+	p, ok := cfg.Active()
+	return cfg.ActiveProfile, p, ok
+}
+
+// envSetting returns the ADO_<KEY> environment variable for a config key.
+// Like flags, it overrides both the active profile and the config file.
+func envSetting(key string) string {
+	return os.Getenv("ADO_" + strings.ToUpper(key))
+}
+
+// defaultOrganization returns the --org flag, else ADO_ORGANIZATION, else the
+// active profile's organization, else the top-level config value.
+func defaultOrganization() string {
+	if orgFlag != "" {
+		return orgFlag
+	}
+	if v := envSetting("organization"); v != "" {
+		return v
+	}
+	if _, p, ok := activeProfile(); ok && p.Organization != "" {
+		return p.Organization
+	}
+	return viper.GetString("organization")
+}
+
+// defaultProject returns ADO_PROJECT, else the active profile's project, else
+// the top-level config value.
+func defaultProject() string {
+	if v := envSetting("project"); v != "" {
+		return v
+	}
+	if _, p, ok := activeProfile(); ok && p.Project != "" {
+		return p.Project
+	}
+	return viper.GetString("project")
+}
+
 func init() {
+	// Profile flags
+	configProfileAddCmd.Flags().String("organization", "", "Azure DevOps organization name (required)")
+	configProfileAddCmd.Flags().String("project", "", "Default project name or ID")
+	configProfileUseCmd.Flags().Bool("none", false, "Deactivate the current profile")
+
+	configProfileCmd.AddCommand(configProfileAddCmd)
+	configProfileCmd.AddCommand(configProfileUseCmd)
+	configProfileCmd.AddCommand(configProfileListCmd)
+
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configProfileCmd)
 
 	rootCmd.AddCommand(configCmd)
 }
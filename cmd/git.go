@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// gitOutput runs git with the given arguments in the current directory and
+// returns its trimmed stdout.
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("running git %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// currentGitBranch returns the branch checked out in the current directory.
+func currentGitBranch() (string, error) {
+	branch, err := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if branch == "HEAD" {
+		return "", fmt.Errorf("not on a branch (detached HEAD)")
+	}
+	return branch, nil
+}
+
+// originRepoName returns the Azure DevOps repository name of the origin remote.
+func originRepoName() (string, error) {
+	remote, err := gitOutput("remote", "get-url", "origin")
+	if err != nil {
+		return "", err
+	}
+	name := repoNameFromRemote(remote)
+	if name == "" {
+		return "", fmt.Errorf("origin %q is not an Azure DevOps repository URL", remote)
+	}
+	return name, nil
+}
+
+// repoNameFromRemote extracts the repository name from an Azure DevOps remote URL:
+//
+//	https://dev.azure.com/{org}/{project}/_git/{repo}
+//	https://{org}.visualstudio.com/{project}/_git/{repo}
+//	git@ssh.dev.azure.com:v3/{org}/{project}/{repo}
+//
+// It returns "" if the URL is not recognized.
+func repoNameFromRemote(remote string) string {
+	var name string
+	switch {
+	case strings.Contains(remote, "/_git/"):
+		name = remote[strings.LastIndex(remote, "/_git/")+len("/_git/"):]
+	case strings.Contains(remote, ":v3/"):
+		name = remote[strings.LastIndex(remote, "/")+1:]
+	default:
+		return ""
+	}
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "/"), ".git")
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	return name
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var iterationCmd = &cobra.Command{
+	Use:     "iteration",
+	Aliases: []string{"iterations", "sprint"},
+	Short:   "Manage team iterations",
+	Long:    "List the iterations (sprints) selected by a team.",
+}
+
+// --- ado iteration list ---
+
+var iterationListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List team iterations",
+	Long: `List the iterations a team has selected, with their paths and dates.
+
+  ado iteration list --team "Web Team"
+  ado iteration list --current`,
+	RunE: runIterationList,
+}
+
+func runIterationList(cmd *cobra.Command, args []string) error {
+	team, _ := cmd.Flags().GetString("team")
+	current, _ := cmd.Flags().GetBool("current")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	iterations, err := client.ListIterations(ctx, project, team, current)
+	if err != nil {
+		return fmt.Errorf("listing iterations: %w", err)
+	}
+
+	if len(iterations) == 0 {
+		if OutputFormat() == "json" {
+			return printJSON([]interface{}{})
+		} else {
+			fmt.Fprintln(os.Stderr, "No iterations found.")
+		}
+		return nil
+	}
+
+	defer startPager()()
+
+	if ok, err := printTemplate(iterations); ok {
+		return err
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(iterations)
+	case "plain":
+		for _, it := range iterations {
+			fmt.Printf("%s\t%s\t%s\t%s\n", it.Name, it.Path,
+				shortDate(it.Attributes.StartDate), shortDate(it.Attributes.FinishDate))
+		}
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{"Name", "Path", "Start", "Finish", "TimeFrame"})
+		for _, it := range iterations {
+			_ = w.Write([]string{it.Name, it.Path,
+				shortDate(it.Attributes.StartDate), shortDate(it.Attributes.FinishDate), it.Attributes.TimeFrame})
+		}
+		w.Flush()
+		return w.Error()
+	default: // table
+		fmt.Fprintf(os.Stdout, "  %-20s %-45s %-10s  %-10s\n", "Name", "Path", "Start", "Finish")
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", 95))
+		for _, it := range iterations {
+			marker := " "
+			if it.Attributes.TimeFrame == "current" {
+				marker = "*"
+			}
+			fmt.Fprintf(os.Stdout, "%s %-20s %-45s %-10s  %-10s\n", marker,
+				truncate(it.Name, 20), truncate(it.Path, 45),
+				shortDate(it.Attributes.StartDate), shortDate(it.Attributes.FinishDate))
+		}
+	}
+	return nil
+}
+
+// shortDate trims an ISO 8601 timestamp to its date part.
+func shortDate(s string) string {
+	if len(s) > 10 {
+		return s[:10]
+	}
+	return s
+}
+
+func init() {
+	// List flags
+	addProjectFlags(iterationListCmd)
+	supportsTemplate(iterationListCmd)
+	iterationListCmd.Flags().String("team", "", "Team name (default: the project's default team)")
+	iterationListCmd.Flags().Bool("current", false, "Show only the current iteration")
+
+	iterationCmd.AddCommand(iterationListCmd)
+
+	rootCmd.AddCommand(iterationCmd)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gyurisc/adocli/internal/api"
+	"github.com/jmespath/go-jmespath"
+	"github.com/spf13/cobra"
+)
+
+// printJSON writes v to stdout as indented JSON. If --query is set, the
+// JMESPath expression is applied to the JSON form of v first, so field names
+// match the JSON output (e.g. [].fields."System.Title").
+func printJSON(v interface{}) error {
+	if queryFlag != "" {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var doc interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		v, err = jmespath.Search(queryFlag, doc)
+		if err != nil {
+			return fmt.Errorf("evaluating --query %q: %w", queryFlag, err)
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// addIDOnlyFlag registers --id-only on commands that create or change a
+// single object.
+func addIDOnlyFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("id-only", false, "Print only the ID, e.g. for ID=$(ado ...)")
+}
+
+// commandOutputFormat is OutputFormat, except that it returns "id" when the
+// command's --id-only flag is set.
+func commandOutputFormat(cmd *cobra.Command) string {
+	if idOnly, _ := cmd.Flags().GetBool("id-only"); idOnly {
+		return "id"
+	}
+	return OutputFormat()
+}
+
+// resultWrittenError wraps an error returned by a command that had already
+// printed its result, such as a bulk command reporting per-item failures.
+type resultWrittenError struct {
+	err error
+}
+
+func (e *resultWrittenError) Error() string { return e.err.Error() }
+func (e *resultWrittenError) Unwrap() error { return e.err }
+
+// afterResult marks err as returned after the command's result was printed.
+// In JSON mode Execute then prints no error document, so stdout stays a
+// single JSON value; the error still goes to stderr and sets the exit status.
+func afterResult(err error) error {
+	return &resultWrittenError{err: err}
+}
+
+// jsonError is the shape of the error object written by printJSONError.
+type jsonError struct {
+	StatusCode int    `json:"statusCode,omitempty"`
+	TypeKey    string `json:"typeKey,omitempty"`
+	Message    string `json:"message"`
+}
+
+// printJSONError writes err to w as {"error": {...}} so JSON consumers get a
+// parseable result even when a command fails. API errors carry their HTTP
+// status and the server's type key.
+func printJSONError(w io.Writer, err error) {
+	out := jsonError{Message: err.Error()}
+	var apiErr *api.Error
+	if errors.As(err, &apiErr) {
+		out.StatusCode = apiErr.StatusCode
+		out.TypeKey = apiErr.TypeKey
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(map[string]jsonError{"error": out})
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		max  int
+		want string
+	}{
+		{"ascii shorter", "hello", 10, "hello"},
+		{"ascii exact length", "hello", 5, "hello"},
+		{"ascii truncated", "hello world", 8, "hello..."},
+		{"multibyte shorter", "Café ☕", 6, "Café ☕"},
+		{"multibyte exact length", "Café ☕ deployment", 17, "Café ☕ deployment"},
+		{"multibyte truncated", "Café ☕ deployment", 9, "Café ☕..."},
+		{"emoji truncated", "🚀🚀🚀🚀🚀", 4, "🚀..."},
+		{"width equals ellipsis", "héllo", 3, "hél"},
+		{"width shorter than ellipsis", "héllo", 2, "hé"},
+		{"zero width", "héllo", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.s, tt.max)
+			if got != tt.want {
+				t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncate(%q, %d) = %q is not valid UTF-8", tt.s, tt.max, got)
+			}
+		})
+	}
+}
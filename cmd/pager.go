@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const defaultPager = "less -FRX"
+
+// pagerActive is set while stdout is redirected into a pager, so color
+// detection still treats the output as going to a terminal.
+var pagerActive bool
+
+// startPager pipes stdout through $PAGER (default "less -FRX") for long
+// table or plain output. "less -F" exits immediately when the output fits on
+// one screen. JSON output, non-terminal stdout, and --no-pager bypass it.
+// The returned function flushes the pager and waits for it to exit.
+func startPager() func() {
+	if noPager || OutputFormat() == "json" || !isTTY(os.Stdout) {
+		return func() {}
+	}
+
+	pager, ok := os.LookupEnv("PAGER")
+	if !ok {
+		pager = defaultPager
+	}
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		return func() {}
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return func() {}
+	}
+	c := exec.Command(args[0], args[1:]...)
+	c.Stdin, c.Stdout, c.Stderr = r, os.Stdout, os.Stderr
+	if err := c.Start(); err != nil {
+		r.Close()
+		w.Close()
+		return func() {}
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	pagerActive = true
+	return func() {
+		w.Close()
+		_ = c.Wait()
+		r.Close()
+		os.Stdout = stdout
+		pagerActive = false
+	}
+}
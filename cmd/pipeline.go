@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gyurisc/adocli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var pipelineCmd = &cobra.Command{
+	Use:     "pipeline",
+	Aliases: []string{"pipelines"},
+	Short:   "Manage Azure Pipelines",
+	Long:    "List and run Azure Pipelines.",
+}
+
+// --- ado pipeline list ---
+
+var pipelineListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pipelines",
+	RunE:  runPipelineList,
+}
+
+func runPipelineList(cmd *cobra.Command, args []string) error {
+	top, _ := cmd.Flags().GetInt("top")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	pipelines, err := client.ListPipelines(ctx, project, top)
+	if err != nil {
+		return fmt.Errorf("listing pipelines: %w", err)
+	}
+
+	if len(pipelines) == 0 {
+		if OutputFormat() == "json" {
+			return printJSON([]interface{}{})
+		} else {
+			fmt.Fprintln(os.Stderr, "No pipelines found.")
+		}
+		return nil
+	}
+
+	defer startPager()()
+
+	if ok, err := printTemplate(pipelines); ok {
+		return err
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(pipelines)
+	case "plain":
+		for _, p := range pipelines {
+			fmt.Printf("%d\t%s\n", p.ID, p.Name)
+		}
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{"ID", "Name", "Folder"})
+		for _, p := range pipelines {
+			_ = w.Write([]string{strconv.Itoa(p.ID), p.Name, p.Folder})
+		}
+		w.Flush()
+		return w.Error()
+	default: // table
+		fmt.Fprintf(os.Stdout, "%-8s %-50s %s\n", "ID", "Name", "Folder")
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", 90))
+		for _, p := range pipelines {
+			fmt.Fprintf(os.Stdout, "%-8d %-50s %s\n", p.ID, truncate(p.Name, 50), p.Folder)
+		}
+	}
+	return nil
+}
+
+// --- ado pipeline run ---
+
+var pipelineRunCmd = &cobra.Command{
+	Use:   "run <id>",
+	Short: "Run a pipeline",
+	Long: `Queue a new run of a pipeline.
+
+  ado pipeline run 12 --branch feature/login --param env=staging --param verbose=true
+
+With --wait the command polls until the run finishes, printing state changes
+to stderr, and exits non-zero unless the run succeeded. Combine it with
+--timeout to bound how long a script waits:
+  ado pipeline run 12 --wait --timeout 30m`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPipelineRun,
+}
+
+func runPipelineRun(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pipeline ID: %s", args[0])
+	}
+	branch, _ := cmd.Flags().GetString("branch")
+	paramFlags, _ := cmd.Flags().GetStringArray("param")
+	wait, _ := cmd.Flags().GetBool("wait")
+
+	params := api.RunParams{}
+	if branch != "" {
+		params.Branch = ensureRef(branch)
+	}
+	for _, p := range paramFlags {
+		key, value, ok := strings.Cut(p, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid --param %q (expected key=value)", p)
+		}
+		if params.TemplateParameters == nil {
+			params.TemplateParameters = map[string]string{}
+		}
+		params.TemplateParameters[strings.TrimSpace(key)] = value
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.RunPipeline(ctx, project, id, params)
+	if err != nil {
+		return fmt.Errorf("running pipeline %d: %w", id, err)
+	}
+	if !wait {
+		return printPipelineRun(run)
+	}
+
+	fmt.Fprintf(os.Stderr, "Queued run %d: %s\n", run.ID, run.WebURL())
+	run, err = waitForPipelineRun(ctx, client, project, id, run)
+	if err != nil {
+		return err
+	}
+	if err := printPipelineRun(run); err != nil {
+		return err
+	}
+	if run.Result != "succeeded" {
+		return afterResult(fmt.Errorf("run %d finished with result %q", run.ID, run.Result))
+	}
+	return nil
+}
+
+// pipelinePollInterval is how often --wait checks the state of a run.
+const pipelinePollInterval = 10 * time.Second
+
+// waitForPipelineRun polls run until it completes, printing each state
+// change to stderr. It stops early when ctx is done (e.g. --timeout).
+func waitForPipelineRun(ctx context.Context, client *api.Client, project string, pipelineID int, run *api.PipelineRun) (*api.PipelineRun, error) {
+	last := run.State
+	fmt.Fprintf(os.Stderr, "Run %d: %s\n", run.ID, last)
+	for run.State != "completed" {
+		if err := sleepCtx(ctx, pipelinePollInterval); err != nil {
+			return nil, fmt.Errorf("waiting for run %d: %w", run.ID, err)
+		}
+		next, err := client.GetPipelineRun(ctx, project, pipelineID, run.ID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching run %d: %w", run.ID, err)
+		}
+		run = next
+		if run.State != last {
+			last = run.State
+			fmt.Fprintf(os.Stderr, "Run %d: %s\n", run.ID, last)
+		}
+	}
+	return run, nil
+}
+
+// printPipelineRun renders a pipeline run in the current output format.
+func printPipelineRun(run *api.PipelineRun) error {
+	switch OutputFormat() {
+	case "json":
+		return printJSON(run)
+	case "plain":
+		fmt.Printf("%d\t%s\t%s\n", run.ID, run.State, run.WebURL())
+	default:
+		state := run.State
+		if run.Result != "" {
+			state += " (" + run.Result + ")"
+		}
+		fmt.Printf("Run %d of %s: %s\n", run.ID, run.Pipeline.Name, state)
+		if u := run.WebURL(); u != "" {
+			fmt.Printf("URL: %s\n", u)
+		}
+	}
+	return nil
+}
+
+func init() {
+	// List flags
+	addProjectFlags(pipelineListCmd)
+	supportsTemplate(pipelineListCmd)
+	pipelineListCmd.Flags().Int("top", 50, "Maximum number of results")
+
+	// Run flags
+	addProjectFlags(pipelineRunCmd)
+	pipelineRunCmd.Flags().String("branch", "", "Branch to run (default: the pipeline's default branch)")
+	pipelineRunCmd.Flags().StringArray("param", nil, "Template parameter as key=value (repeatable)")
+	pipelineRunCmd.Flags().Bool("wait", false, "Wait for the run to finish; exit non-zero unless it succeeds")
+
+	pipelineCmd.AddCommand(pipelineListCmd)
+	pipelineCmd.AddCommand(pipelineRunCmd)
+
+	rootCmd.AddCommand(pipelineCmd)
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"context"
+	"time"
+)
+
+// sleepCtx waits for d, returning early with the context's error if ctx is
+// canceled or its deadline (--timeout) passes first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -1,16 +1,23 @@
 package cmd
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
+	"encoding/csv"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gyurisc/adocli/internal/api"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
+// cliPRLabel is applied to pull requests created by ado when auto_label_cli_prs is enabled.
+const cliPRLabel = "created-via-cli"
+
 var prCmd = &cobra.Command{
 	Use:     "pr",
 	Aliases: []string{"pullrequest"},
@@ -28,6 +35,7 @@ var prListCmd = &cobra.Command{
 }
 
 func runPRList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	client, err := newAPIClient()
 	if err != nil {
 		return err
@@ -40,56 +48,108 @@ func runPRList(cmd *cobra.Command, args []string) error {
 	status, _ := cmd.Flags().GetString("status")
 	creator, _ := cmd.Flags().GetString("creator")
 	reviewer, _ := cmd.Flags().GetString("reviewer")
-	repo, _ := cmd.Flags().GetString("repo")
+	source, _ := cmd.Flags().GetString("source")
+	target, _ := cmd.Flags().GetString("target")
+	repo := resolveRepo(cmd)
 	top, _ := cmd.Flags().GetInt("top")
+	mine, _ := cmd.Flags().GetBool("mine")
+	reviewing, _ := cmd.Flags().GetBool("reviewing")
+
+	if mine {
+		creator = "@me"
+	}
+	if reviewing {
+		reviewer = "@me"
+	}
+	if creator != "" {
+		if creator, err = resolveUserID(ctx, client, creator); err != nil {
+			return fmt.Errorf("resolving --creator: %w", err)
+		}
+	}
+	if reviewer != "" {
+		if reviewer, err = resolveUserID(ctx, client, reviewer); err != nil {
+			return fmt.Errorf("resolving --reviewer: %w", err)
+		}
+	}
 
 	var repoID string
 	if repo != "" {
-		repoID, err = resolveRepoID(client, project, repo)
+		repoID, err = resolveRepoID(ctx, client, project, repo)
 		if err != nil {
 			return err
 		}
 	}
 
-	prs, err := client.ListPullRequests(project, repoID, api.PullRequestQuery{
+	query := api.PullRequestQuery{
 		Status:   status,
 		Creator:  creator,
 		Reviewer: reviewer,
 		Top:      top,
-	})
+	}
+	if source != "" {
+		query.SourceRefName = ensureRef(source)
+	}
+	if target != "" {
+		query.TargetRefName = ensureRef(target)
+	}
+	prs, err := client.ListPullRequests(ctx, project, repoID, query)
 	if err != nil {
 		return fmt.Errorf("listing pull requests: %w", err)
 	}
 
 	if len(prs) == 0 {
 		if OutputFormat() == "json" {
-			fmt.Println("[]")
+			if err := printJSON([]interface{}{}); err != nil {
+				return err
+			}
 		} else {
 			fmt.Fprintln(os.Stderr, "No pull requests found.")
 		}
+		if exitCode, _ := cmd.Flags().GetBool("exit-code"); exitCode {
+			return errNoResults
+		}
 		return nil
 	}
 
+	defer startPager()()
+
+	if ok, err := printTemplate(prs); ok {
+		return err
+	}
+
 	switch OutputFormat() {
 	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(prs)
+		return printJSON(prs)
 	case "plain":
 		for _, pr := range prs {
 			fmt.Printf("%d\t%s\n", pr.ID, pr.Title)
 		}
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{"ID", "Title", "Source", "Target", "Status", "Creator"})
+		for _, pr := range prs {
+			_ = w.Write([]string{
+				strconv.Itoa(pr.ID),
+				pr.Title,
+				shortBranch(pr.SourceBranch),
+				shortBranch(pr.TargetBranch),
+				pr.Status,
+				pr.CreatedBy.DisplayName,
+			})
+		}
+		w.Flush()
+		return w.Error()
 	default: // table
 		fmt.Fprintf(os.Stdout, "%-8s %-50s %-20s %-20s %-12s %-20s\n",
 			"ID", "Title", "Source", "Target", "Status", "Creator")
 		fmt.Fprintln(os.Stdout, strings.Repeat("-", 134))
 		for _, pr := range prs {
-			fmt.Fprintf(os.Stdout, "%-8d %-50s %-20s %-20s %-12s %-20s\n",
+			fmt.Fprintf(os.Stdout, "%-8d %-50s %-20s %-20s %s %-20s\n",
 				pr.ID,
 				truncate(pr.Title, 50),
 				truncate(shortBranch(pr.SourceBranch), 20),
 				truncate(shortBranch(pr.TargetBranch), 20),
-				pr.Status,
+				colorState(fmt.Sprintf("%-12s", pr.Status), pr.Status),
 				truncate(pr.CreatedBy.DisplayName, 20),
 			)
 		}
@@ -113,6 +173,7 @@ func runPRShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid pull request ID: %s", args[0])
 	}
 
+	ctx := cmd.Context()
 	client, err := newAPIClient()
 	if err != nil {
 		return err
@@ -122,16 +183,20 @@ func runPRShow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	pr, err := client.GetPullRequest(project, id)
+	pr, err := client.GetPullRequest(ctx, project, id)
 	if err != nil {
 		return fmt.Errorf("fetching pull request %d: %w", id, err)
 	}
 
+	defer startPager()()
+
+	if ok, err := printTemplate(pr); ok {
+		return err
+	}
+
 	switch OutputFormat() {
 	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(pr)
+		return printJSON(pr)
 	case "plain":
 		fmt.Printf("%d\t%s\n", pr.ID, pr.Title)
 	default: // table
@@ -147,7 +212,11 @@ func runPRShow(cmd *cobra.Command, args []string) error {
 		if len(pr.Reviewers) > 0 {
 			fmt.Println("\nReviewers:")
 			for _, r := range pr.Reviewers {
-				fmt.Printf("  - %s (%s)\n", r.DisplayName, voteString(r.Vote))
+				required := ""
+				if r.IsRequired {
+					required = ", required"
+				}
+				fmt.Printf("  - %s (%s%s)\n", r.DisplayName, voteString(r.Vote), required)
 			}
 		}
 		if pr.Description != "" {
@@ -162,11 +231,19 @@ func runPRShow(cmd *cobra.Command, args []string) error {
 var prCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a pull request",
-	Long:  "Create a new pull request in Azure DevOps.",
-	RunE:  runPRCreate,
+	Long: `Create a new pull request in Azure DevOps.
+
+When run inside a clone, --repo defaults to the origin remote's repository,
+--source to the current branch, and --target to the repository's default branch.
+
+Use --auto-complete to have the pull request merge as soon as its policies
+pass, with the merge flags controlling how:
+  ado pr create --title "Fix typo" --auto-complete --merge-strategy squash --delete-source-branch`,
+	RunE: runPRCreate,
 }
 
 func runPRCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	client, err := newAPIClient()
 	if err != nil {
 		return err
@@ -176,32 +253,59 @@ func runPRCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	repo, _ := cmd.Flags().GetString("repo")
+	repo := resolveRepo(cmd)
 	title, _ := cmd.Flags().GetString("title")
 	source, _ := cmd.Flags().GetString("source")
 	target, _ := cmd.Flags().GetString("target")
 	desc, _ := cmd.Flags().GetString("description")
 	reviewersStr, _ := cmd.Flags().GetString("reviewers")
+	requiredStr, _ := cmd.Flags().GetString("required-reviewers")
 	draft, _ := cmd.Flags().GetBool("draft")
+	workItemIDs, _ := cmd.Flags().GetIntSlice("work-items")
+	autoComplete, _ := cmd.Flags().GetBool("auto-complete")
 
-	if repo == "" {
-		return fmt.Errorf("--repo is required")
-	}
 	if title == "" {
 		return fmt.Errorf("--title is required")
 	}
-	if source == "" {
-		return fmt.Errorf("--source is required")
+	if !autoComplete {
+		for _, name := range []string{"merge-strategy", "delete-source-branch", "merge-commit-message"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s requires --auto-complete", name)
+			}
+		}
 	}
-	if target == "" {
-		return fmt.Errorf("--target is required")
+	opts, err := completionOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	// Inside a clone, default the repository and source branch from git.
+	if repo == "" {
+		if repo, err = originRepoName(); err != nil {
+			return fmt.Errorf("--repo not given and could not be detected: %w", err)
+		}
+	}
+	if source == "" {
+		if source, err = currentGitBranch(); err != nil {
+			return fmt.Errorf("--source not given and could not be detected: %w", err)
+		}
 	}
 
-	repoID, err := resolveRepoID(client, project, repo)
+	repoID, err := resolveRepoID(ctx, client, project, repo)
 	if err != nil {
 		return err
 	}
 
+	if target == "" {
+		r, err := client.GetRepository(ctx, project, repoID)
+		if err != nil {
+			return fmt.Errorf("fetching repository %q: %w", repo, err)
+		}
+		if r.DefaultBranch == "" {
+			return fmt.Errorf("--target not given and repository %q has no default branch", repo)
+		}
+		target = r.DefaultBranch
+	}
+
 	input := api.CreatePRInput{
 		SourceRefName: ensureRef(source),
 		TargetRefName: ensureRef(target),
@@ -210,33 +314,98 @@ func runPRCreate(cmd *cobra.Command, args []string) error {
 		IsDraft:       draft,
 	}
 
-	if reviewersStr != "" {
-		for _, r := range strings.Split(reviewersStr, ",") {
+	for _, list := range []struct {
+		names    string
+		required bool
+	}{{reviewersStr, false}, {requiredStr, true}} {
+		for _, r := range strings.Split(list.names, ",") {
 			r = strings.TrimSpace(r)
-			if r != "" {
-				input.Reviewers = append(input.Reviewers, api.IdentityRef{ID: r})
+			if r == "" {
+				continue
+			}
+			identity, err := client.ResolveIdentity(ctx, r)
+			if err != nil {
+				return fmt.Errorf("resolving reviewer: %w", err)
 			}
+			input.Reviewers = append(input.Reviewers, api.IdentityRef{ID: identity.ID, IsRequired: list.required})
 		}
 	}
 
-	pr, err := client.CreatePullRequest(project, repoID, input)
+	pr, err := client.CreatePullRequest(ctx, project, repoID, input)
 	if err != nil {
 		return fmt.Errorf("creating pull request: %w", err)
 	}
+	repoName := pr.Repository.Name
+	if repoName == "" {
+		repoName = repo
+	}
+	pr.WebURL = client.PullRequestWebURL(project, repoName, pr.ID)
 
-	switch OutputFormat() {
+	if viper.GetBool("auto_label_cli_prs") {
+		if _, err := client.AddPullRequestLabel(ctx, project, repoID, pr.ID, cliPRLabel); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: labeling pull request %d: %v\n", pr.ID, err)
+		}
+	}
+
+	linkFailed := 0
+	if len(workItemIDs) > 0 {
+		linkFailed = linkPRWorkItems(ctx, client, project, repoID, pr.ID, workItemIDs)
+	}
+
+	// The pull request exists by now, so a failure here is reported after
+	// printing it rather than instead of it.
+	var autoCompleteErr error
+	if autoComplete {
+		var updated *api.PullRequest
+		if updated, autoCompleteErr = setAutoComplete(ctx, client, project, repoID, pr.ID, opts); autoCompleteErr == nil {
+			updated.WebURL = pr.WebURL
+			pr = updated
+		}
+	}
+
+	switch commandOutputFormat(cmd) {
+	case "id":
+		fmt.Println(pr.ID)
 	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(pr)
+		if err := printJSON(pr); err != nil {
+			return err
+		}
 	case "plain":
 		fmt.Printf("%d\t%s\n", pr.ID, pr.Title)
 	default:
 		fmt.Printf("Created pull request %d: %s\n", pr.ID, pr.Title)
 	}
+
+	if autoCompleteErr != nil {
+		return afterResult(autoCompleteErr)
+	}
+	if linkFailed > 0 {
+		return afterResult(fmt.Errorf("failed to link %d of %d work items", linkFailed, len(workItemIDs)))
+	}
 	return nil
 }
 
+// linkPRWorkItems links each work item to the pull request, reporting progress
+// on stderr. It returns the number of links that failed.
+func linkPRWorkItems(ctx context.Context, client *api.Client, project, repoID string, prID int, ids []int) int {
+	projectID, err := client.ProjectID(ctx, project)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: linking work items: resolving project ID: %v\n", err)
+		return len(ids)
+	}
+
+	failed := 0
+	for _, id := range ids {
+		if _, err := client.LinkWorkItemToPR(ctx, project, id, projectID, repoID, prID); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to link work item %d: %v\n", id, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Linked work item %d\n", id)
+	}
+	return failed
+}
+
 // --- ado pr approve ---
 
 var prApproveCmd = &cobra.Command{
@@ -265,12 +434,46 @@ func runPRReject(cmd *cobra.Command, args []string) error {
 	return votePR(cmd, args, -10, "Rejected")
 }
 
+// --- ado pr vote ---
+
+var prVoteCmd = &cobra.Command{
+	Use:   "vote <id>",
+	Short: "Vote on a pull request",
+	Long: `Set your vote on a pull request. Valid votes:
+  approve                   10
+  approve-with-suggestions   5
+  wait                      -5 (waiting for author)
+  reject                   -10
+  reset                      0 (no vote)`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRVote,
+}
+
+// voteValues maps --vote names to Azure DevOps vote values.
+var voteValues = map[string]int{
+	"approve":                  10,
+	"approve-with-suggestions": 5,
+	"reset":                    0,
+	"wait":                     -5,
+	"reject":                   -10,
+}
+
+func runPRVote(cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("vote")
+	vote, ok := voteValues[name]
+	if !ok {
+		return fmt.Errorf("invalid --vote %q (must be approve, approve-with-suggestions, wait, reject, or reset)", name)
+	}
+	return votePR(cmd, args, vote, voteString(vote))
+}
+
 func votePR(cmd *cobra.Command, args []string, vote int, label string) error {
 	id, err := strconv.Atoi(args[0])
 	if err != nil {
 		return fmt.Errorf("invalid pull request ID: %s", args[0])
 	}
 
+	ctx := cmd.Context()
 	client, err := newAPIClient()
 	if err != nil {
 		return err
@@ -281,115 +484,1434 @@ func votePR(cmd *cobra.Command, args []string, vote int, label string) error {
 	}
 
 	// Get the PR to find the repository ID.
-	pr, err := client.GetPullRequest(project, id)
+	pr, err := client.GetPullRequest(ctx, project, id)
 	if err != nil {
 		return fmt.Errorf("fetching pull request %d: %w", id, err)
 	}
 
 	// Get the authenticated user's ID.
-	conn, err := client.GetConnectionData()
+	conn, err := client.GetConnectionData(ctx)
 	if err != nil {
 		return fmt.Errorf("getting authenticated user: %w", err)
 	}
 
-	if err := client.VotePullRequest(project, pr.Repository.ID, id, conn.AuthenticatedUser.ID, vote); err != nil {
+	if err := client.VotePullRequest(ctx, project, pr.Repository.ID, id, conn.AuthenticatedUser.ID, vote); err != nil {
 		return fmt.Errorf("voting on pull request %d: %w", id, err)
 	}
 
-	switch OutputFormat() {
+	switch commandOutputFormat(cmd) {
+	case "id":
+		fmt.Println(id)
 	case "json":
 		out := map[string]interface{}{
 			"pullRequestId": id,
 			"vote":          vote,
 			"status":        label,
 		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(out)
+		return printJSON(out)
 	case "plain":
 		fmt.Printf("%d\t%s\n", id, label)
 	default:
-		fmt.Printf("%s pull request %d\n", label, id)
+		fmt.Printf("Set vote on pull request %d to %s\n", id, label)
 	}
 	return nil
 }
 
-// --- helpers ---
+// --- ado pr complete ---
+
+var prCompleteCmd = &cobra.Command{
+	Use:   "complete <id>",
+	Short: "Complete (merge) a pull request",
+	Long: `Complete a pull request, merging it into the target branch.
+
+Branch policies must be satisfied; otherwise the Azure DevOps error is shown.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRComplete,
+}
 
-func resolveRepoID(client *api.Client, project, repoName string) (string, error) {
-	repos, err := client.ListRepositories(project)
+func runPRComplete(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
 	if err != nil {
-		return "", fmt.Errorf("listing repositories: %w", err)
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
 	}
-	for _, r := range repos {
-		if strings.EqualFold(r.Name, repoName) {
-			return r.ID, nil
-		}
+
+	opts, err := completionOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the PR to find the repository ID and latest source commit.
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	pr, err = client.CompletePullRequest(ctx, project, pr.Repository.ID, id, pr.LastMergeSourceCommit, opts)
+	if err != nil {
+		return fmt.Errorf("completing pull request %d: %w", id, err)
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(pr)
+	case "plain":
+		fmt.Printf("%d\t%s\n", pr.ID, pr.Status)
+	default:
+		fmt.Printf("Completed pull request %d: %s (%s)\n", pr.ID, pr.Title, pr.Status)
 	}
-	return "", fmt.Errorf("repository %q not found in project %q", repoName, project)
+	return nil
 }
 
-func shortBranch(ref string) string {
-	return strings.TrimPrefix(ref, "refs/heads/")
+// --- ado pr abandon ---
+
+var prAbandonCmd = &cobra.Command{
+	Use:   "abandon <id>",
+	Short: "Abandon a pull request",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRAbandon,
 }
 
-func ensureRef(branch string) string {
-	if strings.HasPrefix(branch, "refs/") {
-		return branch
+func runPRAbandon(cmd *cobra.Command, args []string) error {
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes && isTTY(os.Stdin) && !confirm(fmt.Sprintf("About to abandon pull request %s. Are you sure?", args[0])) {
+		return errAborted
 	}
-	return "refs/heads/" + branch
+	return setPRStatus(cmd, args, "abandoned", "Abandoned")
 }
 
-func voteString(vote int) string {
-	switch vote {
-	case 10:
-		return "Approved"
-	case 5:
-		return "Approved with suggestions"
-	case 0:
-		return "No vote"
-	case -5:
-		return "Waiting for author"
-	case -10:
-		return "Rejected"
+// --- ado pr reactivate ---
+
+var prReactivateCmd = &cobra.Command{
+	Use:   "reactivate <id>",
+	Short: "Reactivate an abandoned pull request",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRReactivate,
+}
+
+func runPRReactivate(cmd *cobra.Command, args []string) error {
+	return setPRStatus(cmd, args, "active", "Reactivated")
+}
+
+func setPRStatus(cmd *cobra.Command, args []string, status, label string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the PR to find the repository ID.
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	pr, err = client.SetPullRequestStatus(ctx, project, pr.Repository.ID, id, status)
+	if err != nil {
+		return fmt.Errorf("updating pull request %d: %w", id, err)
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(pr)
+	case "plain":
+		fmt.Printf("%d\t%s\n", pr.ID, pr.Status)
 	default:
-		return fmt.Sprintf("%d", vote)
+		fmt.Printf("%s pull request %d: %s\n", label, pr.ID, pr.Title)
 	}
+	return nil
 }
 
-func init() {
-	// List flags
-	prListCmd.Flags().StringP("project", "p", "", "Project name")
-	prListCmd.Flags().String("status", "", "Filter by status (active, completed, abandoned, all)")
-	prListCmd.Flags().String("creator", "", "Filter by creator ID")
-	prListCmd.Flags().String("reviewer", "", "Filter by reviewer ID")
-	prListCmd.Flags().String("repo", "", "Repository name")
-	prListCmd.Flags().Int("top", 20, "Maximum number of results")
+// --- ado pr add-reviewer ---
 
-	// Show flags
-	prShowCmd.Flags().StringP("project", "p", "", "Project name")
+var prAddReviewerCmd = &cobra.Command{
+	Use:   "add-reviewer <id>",
+	Short: "Add a reviewer to a pull request",
+	Long: `Add a reviewer to a pull request. The reviewer may be given as an
+identity ID, email, or display name.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRAddReviewer,
+}
 
-	// Create flags
-	prCreateCmd.Flags().StringP("project", "p", "", "Project name")
-	prCreateCmd.Flags().String("repo", "", "Repository name (required)")
-	prCreateCmd.Flags().String("title", "", "Pull request title (required)")
-	prCreateCmd.Flags().String("source", "", "Source branch (required)")
-	prCreateCmd.Flags().String("target", "", "Target branch (required)")
-	prCreateCmd.Flags().String("description", "", "Pull request description")
-	prCreateCmd.Flags().String("reviewers", "", "Comma-separated reviewer IDs")
-	prCreateCmd.Flags().Bool("draft", false, "Create as draft pull request")
+func runPRAddReviewer(cmd *cobra.Command, args []string) error {
+	return changePRReviewer(cmd, args, true)
+}
 
-	// Approve flags
-	prApproveCmd.Flags().StringP("project", "p", "", "Project name")
+// --- ado pr remove-reviewer ---
 
-	// Reject flags
-	prRejectCmd.Flags().StringP("project", "p", "", "Project name")
+var prRemoveReviewerCmd = &cobra.Command{
+	Use:   "remove-reviewer <id>",
+	Short: "Remove a reviewer from a pull request",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRRemoveReviewer,
+}
+
+func runPRRemoveReviewer(cmd *cobra.Command, args []string) error {
+	return changePRReviewer(cmd, args, false)
+}
+
+func changePRReviewer(cmd *cobra.Command, args []string, add bool) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+	reviewer, _ := cmd.Flags().GetString("reviewer")
+	if reviewer == "" {
+		return fmt.Errorf("--reviewer is required")
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	identity, err := client.ResolveIdentity(ctx, reviewer)
+	if err != nil {
+		return fmt.Errorf("resolving reviewer: %w", err)
+	}
+
+	// Get the PR to find the repository ID.
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	label := "Removed"
+	if add {
+		required, _ := cmd.Flags().GetBool("required")
+		if _, err := client.AddPullRequestReviewer(ctx, project, pr.Repository.ID, id, identity.ID, required); err != nil {
+			return fmt.Errorf("adding reviewer to pull request %d: %w", id, err)
+		}
+		label = "Added"
+	} else {
+		if err := client.RemovePullRequestReviewer(ctx, project, pr.Repository.ID, id, identity.ID); err != nil {
+			return fmt.Errorf("removing reviewer from pull request %d: %w", id, err)
+		}
+	}
+
+	name := identity.DisplayName
+	if name == "" {
+		name = identity.ID
+	}
+	switch OutputFormat() {
+	case "json":
+		out := map[string]interface{}{
+			"pullRequestId": id,
+			"reviewer":      identity,
+			"status":        label,
+		}
+		return printJSON(out)
+	case "plain":
+		fmt.Printf("%d\t%s\t%s\n", id, identity.ID, label)
+	default:
+		fmt.Printf("%s reviewer %s on pull request %d\n", label, name, id)
+	}
+	return nil
+}
+
+// --- ado pr comment ---
+
+var prCommentCmd = &cobra.Command{
+	Use:   "comment <id>",
+	Short: "Comment on a pull request",
+	Long: `Start a new comment thread on a pull request.
+
+  ado pr comment 42 --text "Looks good overall"
+  ado pr comment 42 --text "Off by one?" --file src/loop.go --line 17`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRComment,
+}
+
+func runPRComment(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+
+	text, _ := cmd.Flags().GetString("text")
+	file, _ := cmd.Flags().GetString("file")
+	line, _ := cmd.Flags().GetInt("line")
+
+	if text == "" {
+		return fmt.Errorf("--text is required")
+	}
+	if line > 0 && file == "" {
+		return fmt.Errorf("--line requires --file")
+	}
+
+	var threadCtx *api.ThreadContext
+	if file != "" {
+		threadCtx = &api.ThreadContext{FilePath: "/" + strings.TrimPrefix(file, "/")}
+		if line > 0 {
+			threadCtx.RightFileStart = &api.FilePosition{Line: line, Offset: 1}
+			threadCtx.RightFileEnd = &api.FilePosition{Line: line, Offset: 1}
+		}
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the PR to find the repository ID.
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	thread, err := client.CreatePullRequestThread(ctx, project, pr.Repository.ID, id, text, threadCtx)
+	if err != nil {
+		return fmt.Errorf("commenting on pull request %d: %w", id, err)
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(thread)
+	case "plain":
+		fmt.Printf("%d\t%d\n", id, thread.ID)
+	default:
+		fmt.Printf("Added comment thread %d on pull request %d\n", thread.ID, id)
+	}
+	return nil
+}
+
+// --- ado pr threads ---
+
+var prThreadsCmd = &cobra.Command{
+	Use:   "threads <id>",
+	Short: "List pull request comment threads",
+	Long:  "List the comment threads on a pull request with their status, file context, and comments.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRThreads,
+}
+
+func runPRThreads(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+	unresolvedOnly, _ := cmd.Flags().GetBool("unresolved-only")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the PR to find the repository ID.
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	all, err := client.ListPullRequestThreads(ctx, project, pr.Repository.ID, id)
+	if err != nil {
+		return fmt.Errorf("listing threads on pull request %d: %w", id, err)
+	}
+
+	threads := make([]api.CommentThread, 0, len(all))
+	for _, t := range all {
+		if t.IsDeleted {
+			continue
+		}
+		if unresolvedOnly && !threadUnresolved(t) {
+			continue
+		}
+		threads = append(threads, t)
+	}
+
+	if len(threads) == 0 {
+		if OutputFormat() == "json" {
+			return printJSON([]interface{}{})
+		} else {
+			fmt.Fprintln(os.Stderr, "No threads found.")
+		}
+		return nil
+	}
+
+	defer startPager()()
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(threads)
+	case "plain":
+		for _, t := range threads {
+			fmt.Printf("%d\t%s\t%s\n", t.ID, t.Status, threadLocation(t))
+		}
+	default:
+		for i, t := range threads {
+			if i > 0 {
+				fmt.Println()
+			}
+			status := t.Status
+			if status == "" {
+				status = "system"
+			}
+			header := fmt.Sprintf("Thread %d [%s]", t.ID, status)
+			if loc := threadLocation(t); loc != "" {
+				header += " " + loc
+			}
+			fmt.Println(header)
+			for _, c := range t.Comments {
+				author := ""
+				if c.Author != nil {
+					author = c.Author.DisplayName
+				}
+				fmt.Printf("  %s (%s):\n", author, c.PublishedDate)
+				for _, line := range strings.Split(strings.TrimRight(c.Content, "\n"), "\n") {
+					fmt.Printf("    %s\n", line)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// threadUnresolved reports whether a thread still needs attention. Threads
+// without a status are system events (votes, pushes) and never need resolving.
+func threadUnresolved(t api.CommentThread) bool {
+	switch t.Status {
+	case "", "closed", "fixed", "wontFix", "byDesign":
+		return false
+	}
+	return true
+}
+
+// threadLocation formats a thread's file context as "path:line", or "" for general threads.
+func threadLocation(t api.CommentThread) string {
+	if t.ThreadContext == nil || t.ThreadContext.FilePath == "" {
+		return ""
+	}
+	if t.ThreadContext.RightFileStart != nil {
+		return fmt.Sprintf("%s:%d", t.ThreadContext.FilePath, t.ThreadContext.RightFileStart.Line)
+	}
+	return t.ThreadContext.FilePath
+}
+
+// --- ado pr policies ---
+
+var prPoliciesCmd = &cobra.Command{
+	Use:   "policies <id>",
+	Short: "Show branch policy status for a pull request",
+	Long:  "Show how each branch policy evaluates for a pull request, and whether it blocks completion.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRPolicies,
+}
+
+func runPRPolicies(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	evals, err := client.GetPolicyEvaluations(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching policies for pull request %d: %w", id, err)
+	}
+
+	if len(evals) == 0 {
+		if OutputFormat() == "json" {
+			return printJSON([]interface{}{})
+		} else {
+			fmt.Fprintln(os.Stderr, "No policies apply to this pull request.")
+		}
+		return nil
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(evals)
+	case "plain":
+		for _, e := range evals {
+			fmt.Printf("%s\t%s\t%t\n", e.DisplayName(), e.Status, e.Configuration.IsBlocking)
+		}
+	default: // table
+		fmt.Fprintf(os.Stdout, "%-50s %-14s %-8s\n", "Policy", "Status", "Blocking")
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", 74))
+		for _, e := range evals {
+			blocking := "no"
+			if e.Configuration.IsBlocking {
+				blocking = "yes"
+			}
+			fmt.Fprintf(os.Stdout, "%-50s %-14s %-8s\n", truncate(e.DisplayName(), 50), e.Status, blocking)
+		}
+	}
+	return nil
+}
+
+// --- ado pr open ---
+
+var prOpenCmd = &cobra.Command{
+	Use:   "open <id>",
+	Short: "Open a pull request in the browser",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPROpen,
+}
+
+func runPROpen(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+	printOnly, _ := cmd.Flags().GetBool("print")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	webURL := client.PullRequestWebURL(project, pr.Repository.Name, pr.ID)
+	if printOnly {
+		fmt.Println(webURL)
+		return nil
+	}
+	return openBrowser(webURL)
+}
+
+// --- ado pr workitems ---
+
+var prWorkItemsCmd = &cobra.Command{
+	Use:   "workitems <id>",
+	Short: "List work items linked to a pull request",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRWorkItems,
+}
+
+func runPRWorkItems(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the PR to find the repository ID.
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	ids, err := client.GetPullRequestWorkItems(ctx, project, pr.Repository.ID, id)
+	if err != nil {
+		return fmt.Errorf("fetching work items linked to pull request %d: %w", id, err)
+	}
+	return fetchAndPrintWorkItems(ctx, client, project, ids, nil)
+}
+
+// --- ado pr files ---
+
+var prFilesCmd = &cobra.Command{
+	Use:   "files <id>",
+	Short: "List files changed in a pull request",
+	Long: `List the files changed by a pull request's latest iteration, compared to the
+target branch.
+
+Use --name-only for a bare list of paths:
+  ado pr files 42 --name-only | xargs grep TODO`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRFiles,
+}
+
+func runPRFiles(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+	nameOnly, _ := cmd.Flags().GetBool("name-only")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	_, _, changes, err := latestPRChanges(ctx, client, project, id)
+	if err != nil {
+		return err
+	}
+
+	if nameOnly {
+		for _, c := range changes {
+			fmt.Println(c.Item.Path)
+		}
+		return nil
+	}
+
+	defer startPager()()
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(changes)
+	case "plain":
+		for _, c := range changes {
+			fmt.Printf("%s\t%s\n", c.ChangeType, c.Item.Path)
+		}
+	default:
+		if len(changes) == 0 {
+			fmt.Println("No files changed.")
+			return nil
+		}
+		fmt.Printf("%-14s %s\n", "Change", "Path")
+		fmt.Println(strings.Repeat("-", 80))
+		for _, c := range changes {
+			path := c.Item.Path
+			if c.OriginalPath != "" {
+				path = c.OriginalPath + " -> " + path
+			}
+			fmt.Printf("%-14s %s\n", c.ChangeType, path)
+		}
+	}
+	return nil
+}
+
+// latestPRChanges returns a pull request, its latest iteration, and the files
+// (not folders) that iteration changes compared to the target branch.
+func latestPRChanges(ctx context.Context, client *api.Client, project string, id int) (*api.PullRequest, *api.PullRequestIteration, []api.PullRequestChange, error) {
+	// Get the PR to find the repository ID.
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	iterations, err := client.GetPullRequestIterations(ctx, project, pr.Repository.ID, id)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("fetching iterations of pull request %d: %w", id, err)
+	}
+	if len(iterations) == 0 {
+		return nil, nil, nil, fmt.Errorf("pull request %d has no iterations", id)
+	}
+	latest := &iterations[len(iterations)-1]
+
+	all, err := client.GetPullRequestChanges(ctx, project, pr.Repository.ID, id, latest.ID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("fetching changes in pull request %d: %w", id, err)
+	}
+
+	changes := make([]api.PullRequestChange, 0, len(all))
+	for _, c := range all {
+		if !c.Item.IsFolder {
+			changes = append(changes, c)
+		}
+	}
+	return pr, latest, changes, nil
+}
+
+// --- ado pr diff ---
+
+var prDiffCmd = &cobra.Command{
+	Use:   "diff <id>",
+	Short: "Show the changes in a pull request as a unified diff",
+	Long: `Show the changes made by a pull request's latest iteration as a unified diff
+against the merge base with the target branch. Binary files are listed but not
+diffed.
+
+Use --stat for a per-file summary of added and removed lines, and --path to
+limit the diff to one file:
+  ado pr diff 42 --stat
+  ado pr diff 42 --path src/main.go`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRDiff,
+}
+
+// fileDiff is the line diff of one changed file.
+type fileDiff struct {
+	Change  api.PullRequestChange
+	Binary  bool
+	Ops     []diffOp
+	Added   int
+	Removed int
+}
+
+func runPRDiff(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+	stat, _ := cmd.Flags().GetBool("stat")
+	pathFilter, _ := cmd.Flags().GetString("path")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	pr, latest, changes, err := latestPRChanges(ctx, client, project, id)
+	if err != nil {
+		return err
+	}
+	if latest.SourceRefCommit == nil {
+		return fmt.Errorf("pull request %d has no source commit", id)
+	}
+	base := latest.CommonRefCommit
+	if base == nil {
+		base = latest.TargetRefCommit
+	}
+	if base == nil {
+		return fmt.Errorf("pull request %d has no target commit", id)
+	}
+
+	if pathFilter != "" {
+		want := "/" + strings.TrimPrefix(pathFilter, "/")
+		var matched []api.PullRequestChange
+		for _, c := range changes {
+			if c.Item.Path == want || c.OriginalPath == want {
+				matched = append(matched, c)
+			}
+		}
+		if len(matched) == 0 {
+			return fmt.Errorf("%s is not changed in pull request %d", want, id)
+		}
+		changes = matched
+	}
+
+	diffs := make([]fileDiff, 0, len(changes))
+	for _, c := range changes {
+		d, err := diffPRChange(ctx, client, project, pr.Repository.ID, base.CommitID, latest.SourceRefCommit.CommitID, c)
+		if err != nil {
+			return err
+		}
+		diffs = append(diffs, d)
+	}
+
+	defer startPager()()
+
+	if stat {
+		printDiffStat(diffs)
+		return nil
+	}
+	for _, d := range diffs {
+		printFileDiff(d)
+	}
+	return nil
+}
+
+// diffPRChange fetches both versions of a changed file and diffs them.
+func diffPRChange(ctx context.Context, client *api.Client, project, repoID, baseCommit, sourceCommit string, c api.PullRequestChange) (fileDiff, error) {
+	d := fileDiff{Change: c}
+
+	var oldText, newText []byte
+	if !strings.Contains(c.ChangeType, "add") {
+		oldPath := c.Item.Path
+		if c.OriginalPath != "" {
+			oldPath = c.OriginalPath
+		}
+		content, err := client.GetItemContent(ctx, project, repoID, oldPath, baseCommit)
+		if err != nil {
+			return d, fmt.Errorf("fetching %s at %s: %w", oldPath, shortCommit(baseCommit), err)
+		}
+		oldText = content
+	}
+	if !strings.Contains(c.ChangeType, "delete") {
+		content, err := client.GetItemContent(ctx, project, repoID, c.Item.Path, sourceCommit)
+		if err != nil {
+			return d, fmt.Errorf("fetching %s at %s: %w", c.Item.Path, shortCommit(sourceCommit), err)
+		}
+		newText = content
+	}
+
+	if bytes.IndexByte(oldText, 0) >= 0 || bytes.IndexByte(newText, 0) >= 0 {
+		d.Binary = true
+		return d, nil
+	}
+	d.Ops = diffLines(splitLines(string(oldText)), splitLines(string(newText)))
+	d.Added, d.Removed = diffCounts(d.Ops)
+	return d, nil
+}
+
+// printFileDiff prints one file in git's unified diff format.
+func printFileDiff(d fileDiff) {
+	c := d.Change
+	oldPath, newPath := c.Item.Path, c.Item.Path
+	if c.OriginalPath != "" {
+		oldPath = c.OriginalPath
+	}
+	fmt.Printf("diff --git a%s b%s\n", oldPath, newPath)
+
+	from, to := "a"+oldPath, "b"+newPath
+	switch {
+	case strings.Contains(c.ChangeType, "add"):
+		fmt.Println("new file")
+		from = "/dev/null"
+	case strings.Contains(c.ChangeType, "delete"):
+		fmt.Println("deleted file")
+		to = "/dev/null"
+	case oldPath != newPath:
+		fmt.Printf("rename from %s\nrename to %s\n", strings.TrimPrefix(oldPath, "/"), strings.TrimPrefix(newPath, "/"))
+	}
+
+	if d.Binary {
+		fmt.Printf("Binary files %s and %s differ\n", from, to)
+		return
+	}
+	if d.Added == 0 && d.Removed == 0 {
+		return
+	}
+	fmt.Printf("--- %s\n+++ %s\n", from, to)
+	writeHunks(os.Stdout, d.Ops)
+}
+
+// printDiffStat prints a per-file summary of added and removed lines.
+func printDiffStat(diffs []fileDiff) {
+	width := 0
+	for _, d := range diffs {
+		width = max(width, len(d.Change.Item.Path))
+	}
+
+	var added, removed int
+	for _, d := range diffs {
+		if d.Binary {
+			fmt.Printf(" %-*s | Bin\n", width, d.Change.Item.Path)
+			continue
+		}
+		added += d.Added
+		removed += d.Removed
+		fmt.Printf(" %-*s | %5d %s%s\n", width, d.Change.Item.Path, d.Added+d.Removed,
+			colorize(strings.Repeat("+", min(d.Added, 40)), colorGreen),
+			colorize(strings.Repeat("-", min(d.Removed, 40)), colorRed))
+	}
+	fmt.Printf(" %d file%s changed, %d insertion%s(+), %d deletion%s(-)\n",
+		len(diffs), plural(len(diffs)), added, plural(added), removed, plural(removed))
+}
+
+// --- ado pr commits ---
+
+var prCommitsCmd = &cobra.Command{
+	Use:   "commits <id>",
+	Short: "List commits in a pull request",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRCommits,
+}
+
+func runPRCommits(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the PR to find the repository ID.
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	commits, err := client.GetPullRequestCommits(ctx, project, pr.Repository.ID, id)
+	if err != nil {
+		return fmt.Errorf("fetching commits of pull request %d: %w", id, err)
+	}
+
+	if len(commits) == 0 {
+		if OutputFormat() == "json" {
+			return printJSON([]interface{}{})
+		} else {
+			fmt.Fprintln(os.Stderr, "No commits found.")
+		}
+		return nil
+	}
+
+	defer startPager()()
+
+	if ok, err := printTemplate(commits); ok {
+		return err
+	}
+
+	for i := range commits {
+		if commits[i].Author == nil {
+			commits[i].Author = &api.GitUserDate{}
+		}
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(commits)
+	case "plain":
+		for _, c := range commits {
+			fmt.Printf("%s\t%s\t%s\t%s\n", c.CommitID, c.Author.Name, c.Author.Date, firstLine(c.Comment))
+		}
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{"Commit", "Author", "Date", "Message"})
+		for _, c := range commits {
+			_ = w.Write([]string{c.CommitID, c.Author.Name, c.Author.Date, firstLine(c.Comment)})
+		}
+		w.Flush()
+		return w.Error()
+	default: // table
+		fmt.Fprintf(os.Stdout, "%-8s  %-20s %-10s  %s\n", "Commit", "Author", "Date", "Message")
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", 100))
+		for _, c := range commits {
+			fmt.Fprintf(os.Stdout, "%-8s  %-20s %-10s  %s\n", shortCommit(c.CommitID),
+				truncate(c.Author.Name, 20), shortDate(c.Author.Date), truncate(firstLine(c.Comment), 56))
+		}
+	}
+	return nil
+}
+
+// firstLine returns the first line of a commit message.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return strings.TrimSpace(line)
+}
+
+// --- ado pr update ---
+
+var prUpdateCmd = &cobra.Command{
+	Use:   "update <id>",
+	Short: "Update a pull request's title or description",
+	Long: `Update the title and/or description of a pull request. Only the flags you
+pass are changed.
+
+The description may be read from a file with @path, or from stdin with -:
+  ado pr update 42 --description @notes.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRUpdate,
+}
+
+func runPRUpdate(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	title, _ := cmd.Flags().GetString("title")
+	desc, _ := cmd.Flags().GetString("description")
+
+	fields := map[string]interface{}{}
+	if cmd.Flags().Changed("title") {
+		if title == "" {
+			return fmt.Errorf("--title cannot be empty")
+		}
+		fields["title"] = title
+	}
+	// An empty --description clears the description.
+	if cmd.Flags().Changed("description") {
+		if desc != "" {
+			if desc, err = loadDescription(cmd, desc); err != nil {
+				return err
+			}
+		}
+		fields["description"] = desc
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("no fields to update (use --title or --description)")
+	}
+
+	// Get the PR to find the repository ID.
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	updated, err := client.UpdatePullRequest(ctx, project, pr.Repository.ID, id, fields)
+	if err != nil {
+		return fmt.Errorf("updating pull request %d: %w", id, err)
+	}
+	updated.WebURL = client.PullRequestWebURL(project, updated.Repository.Name, updated.ID)
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(updated)
+	case "plain":
+		fmt.Printf("%d\t%s\n", updated.ID, updated.Title)
+	default:
+		fmt.Printf("Updated pull request %d: %s\n", updated.ID, updated.Title)
+	}
+	return nil
+}
+
+// --- ado pr publish ---
+
+var prPublishCmd = &cobra.Command{
+	Use:   "publish <id>",
+	Short: "Publish a draft pull request",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRPublish,
+}
+
+func runPRPublish(cmd *cobra.Command, args []string) error {
+	return setPRDraft(cmd, args, false)
+}
+
+// --- ado pr draft ---
+
+var prDraftCmd = &cobra.Command{
+	Use:   "draft <id>",
+	Short: "Convert a pull request back to a draft",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRDraft,
+}
+
+func runPRDraft(cmd *cobra.Command, args []string) error {
+	return setPRDraft(cmd, args, true)
+}
+
+func setPRDraft(cmd *cobra.Command, args []string, draft bool) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the PR to find the repository ID.
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	pr, err = client.UpdatePullRequest(ctx, project, pr.Repository.ID, id, map[string]interface{}{"isDraft": draft})
+	if err != nil {
+		return fmt.Errorf("updating pull request %d: %w", id, err)
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(pr)
+	case "plain":
+		fmt.Printf("%d\t%t\n", pr.ID, pr.IsDraft)
+	default:
+		state := "published"
+		if pr.IsDraft {
+			state = "draft"
+		}
+		fmt.Printf("Pull request %d is now %s: %s\n", pr.ID, state, pr.Title)
+	}
+	return nil
+}
+
+// --- ado pr wait ---
+
+var prWaitCmd = &cobra.Command{
+	Use:   "wait <id>",
+	Short: "Wait for a pull request to be completed or abandoned",
+	Long: `Poll a pull request until it is completed or abandoned, printing status and
+merge status changes to stderr. Exits non-zero if it is abandoned.
+
+With --complete, auto-complete is turned on first (using the merge flags), so
+the pull request merges as soon as its policies pass:
+  ado pr wait 42 --complete --merge-strategy squash --timeout 1h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRWait,
+}
+
+// prPollInterval is how often 'pr wait' checks the pull request.
+const prPollInterval = 15 * time.Second
+
+func runPRWait(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request ID: %s", args[0])
+	}
+	complete, _ := cmd.Flags().GetBool("complete")
+	opts, err := completionOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching pull request %d: %w", id, err)
+	}
+
+	if complete && pr.Status == "active" {
+		if pr, err = setAutoComplete(ctx, client, project, pr.Repository.ID, id, opts); err != nil {
+			return err
+		}
+	}
+
+	last := ""
+	for {
+		state := pr.Status
+		if pr.MergeStatus != "" {
+			state += " (merge: " + pr.MergeStatus + ")"
+		}
+		if state != last {
+			fmt.Fprintf(os.Stderr, "Pull request %d: %s\n", id, state)
+			last = state
+		}
+		if pr.Status == "completed" || pr.Status == "abandoned" {
+			break
+		}
+		if err := sleepCtx(ctx, prPollInterval); err != nil {
+			return fmt.Errorf("waiting for pull request %d: %w", id, err)
+		}
+		if pr, err = client.GetPullRequest(ctx, project, id); err != nil {
+			return fmt.Errorf("fetching pull request %d: %w", id, err)
+		}
+	}
+
+	switch OutputFormat() {
+	case "json":
+		if err := printJSON(pr); err != nil {
+			return err
+		}
+	case "plain":
+		fmt.Printf("%d\t%s\n", pr.ID, pr.Status)
+	default:
+		fmt.Printf("Pull request %d is %s: %s\n", pr.ID, pr.Status, pr.Title)
+	}
+	if pr.Status == "abandoned" {
+		return afterResult(fmt.Errorf("pull request %d was abandoned", id))
+	}
+	return nil
+}
+
+// completionOptionsFromFlags reads the merge flags shared by commands that complete PRs.
+func completionOptionsFromFlags(cmd *cobra.Command) (api.CompletionOptions, error) {
+	var opts api.CompletionOptions
+	opts.MergeStrategy, _ = cmd.Flags().GetString("merge-strategy")
+	opts.DeleteSourceBranch, _ = cmd.Flags().GetBool("delete-source-branch")
+	opts.MergeCommitMessage, _ = cmd.Flags().GetString("merge-commit-message")
+
+	switch opts.MergeStrategy {
+	case "", "squash", "rebase", "noFastForward", "rebaseMerge":
+	default:
+		return opts, fmt.Errorf("invalid --merge-strategy %q (must be squash, rebase, rebaseMerge, or noFastForward)", opts.MergeStrategy)
+	}
+	return opts, nil
+}
+
+// setAutoComplete turns on auto-complete for a pull request on behalf of the
+// authenticated user, who ADO records as having set it.
+func setAutoComplete(ctx context.Context, client *api.Client, project, repoID string, prID int, opts api.CompletionOptions) (*api.PullRequest, error) {
+	conn, err := client.GetConnectionData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting authenticated user: %w", err)
+	}
+	pr, err := client.SetAutoComplete(ctx, project, repoID, prID, conn.AuthenticatedUser.ID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("setting auto-complete on pull request %d: %w", prID, err)
+	}
+	fmt.Fprintf(os.Stderr, "Auto-complete set on pull request %d\n", prID)
+	return pr, nil
+}
+
+// addCompletionFlags registers the merge flags read by completionOptionsFromFlags.
+func addCompletionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("merge-strategy", "", "Merge strategy (squash, rebase, rebaseMerge, noFastForward)")
+	cmd.Flags().Bool("delete-source-branch", false, "Delete the source branch after merging")
+	cmd.Flags().String("merge-commit-message", "", "Merge commit message")
+}
+
+// --- helpers ---
+
+// resolveRepo returns the repository from the --repo flag or the config
+// default. It returns "" if neither is set.
+func resolveRepo(cmd *cobra.Command) string {
+	if r, _ := cmd.Flags().GetString("repo"); r != "" {
+		return r
+	}
+	return viper.GetString("repo")
+}
+
+// resolveRepoID maps a repository name (or ID) to its ID within the project.
+// The project itself may be a name or a GUID.
+func resolveRepoID(ctx context.Context, client *api.Client, project, repoName string) (string, error) {
+	id, err := client.RepositoryID(ctx, project, repoName)
+	if err != nil {
+		return "", fmt.Errorf("listing repositories: %w", err)
+	}
+	if id == "" {
+		return "", fmt.Errorf("repository %q not found in project %q", repoName, project)
+	}
+	return id, nil
+}
+
+// resolveUserID maps @me, an identity GUID, an email, or a display name to
+// an identity ID.
+func resolveUserID(ctx context.Context, client *api.Client, value string) (string, error) {
+	if value == "@me" {
+		conn, err := client.GetConnectionData(ctx)
+		if err != nil {
+			return "", fmt.Errorf("getting authenticated user: %w", err)
+		}
+		return conn.AuthenticatedUser.ID, nil
+	}
+	identity, err := client.ResolveIdentity(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	return identity.ID, nil
+}
+
+func shortBranch(ref string) string {
+	return strings.TrimPrefix(ref, "refs/heads/")
+}
+
+func ensureRef(branch string) string {
+	if strings.HasPrefix(branch, "refs/") {
+		return branch
+	}
+	return "refs/heads/" + branch
+}
+
+func voteString(vote int) string {
+	switch vote {
+	case 10:
+		return "Approved"
+	case 5:
+		return "Approved with suggestions"
+	case 0:
+		return "No vote"
+	case -5:
+		return "Waiting for author"
+	case -10:
+		return "Rejected"
+	default:
+		return fmt.Sprintf("%d", vote)
+	}
+}
+
+func init() {
+	// List flags
+	addProjectFlags(prListCmd)
+	supportsTemplate(prListCmd)
+	prListCmd.Flags().String("status", "", "Filter by status (active, completed, abandoned, all)")
+	prListCmd.Flags().String("creator", "", "Filter by creator: ID, email, display name, or @me")
+	prListCmd.Flags().String("reviewer", "", "Filter by reviewer: ID, email, display name, or @me")
+	prListCmd.Flags().String("source", "", "Filter by source branch")
+	prListCmd.Flags().String("target", "", "Filter by target branch, e.g. main")
+	prListCmd.Flags().String("repo", "", "Repository name (default: config repo, else all repositories)")
+	_ = prListCmd.RegisterFlagCompletionFunc("repo", completeRepos)
+	prListCmd.Flags().Int("top", 20, "Maximum number of results")
+	prListCmd.Flags().Bool("mine", false, "Only pull requests created by you")
+	prListCmd.Flags().Bool("reviewing", false, "Only pull requests where you are a reviewer")
+	prListCmd.Flags().Bool("exit-code", false, "Exit with status 1 if no pull requests match")
+	prListCmd.MarkFlagsMutuallyExclusive("mine", "creator")
+	prListCmd.MarkFlagsMutuallyExclusive("reviewing", "reviewer")
+
+	// Show flags
+	addProjectFlags(prShowCmd)
+	supportsTemplate(prShowCmd)
+
+	// Create flags
+	addProjectFlags(prCreateCmd)
+	prCreateCmd.Flags().String("repo", "", "Repository name (default: config repo, else from the origin remote)")
+	_ = prCreateCmd.RegisterFlagCompletionFunc("repo", completeRepos)
+	prCreateCmd.Flags().String("title", "", "Pull request title (required)")
+	prCreateCmd.Flags().String("source", "", "Source branch (default: current branch)")
+	prCreateCmd.Flags().String("target", "", "Target branch (default: repository default branch)")
+	prCreateCmd.Flags().String("description", "", "Pull request description")
+	prCreateCmd.Flags().String("reviewers", "", "Comma-separated reviewer IDs, emails, or display names")
+	prCreateCmd.Flags().String("required-reviewers", "", "Comma-separated required reviewers, as for --reviewers")
+	prCreateCmd.Flags().Bool("draft", false, "Create as draft pull request")
+	prCreateCmd.Flags().IntSlice("work-items", nil, "Comma-separated work item IDs to link to the pull request")
+	prCreateCmd.Flags().Bool("auto-complete", false, "Set auto-complete right after creating, using the merge flags")
+	addCompletionFlags(prCreateCmd)
+	addIDOnlyFlag(prCreateCmd)
+
+	// Approve flags
+	addProjectFlags(prApproveCmd)
+	addIDOnlyFlag(prApproveCmd)
+
+	// Reject flags
+	addProjectFlags(prRejectCmd)
+	addIDOnlyFlag(prRejectCmd)
+
+	// Vote flags
+	addProjectFlags(prVoteCmd)
+	prVoteCmd.Flags().String("vote", "", "Vote (approve, approve-with-suggestions, wait, reject, reset)")
+	addIDOnlyFlag(prVoteCmd)
+
+	// Complete flags
+	addProjectFlags(prCompleteCmd)
+	addCompletionFlags(prCompleteCmd)
+
+	// Abandon flags
+	addProjectFlags(prAbandonCmd)
+	prAbandonCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+
+	// Reactivate flags
+	addProjectFlags(prReactivateCmd)
+
+	// Add-reviewer flags
+	addProjectFlags(prAddReviewerCmd)
+	prAddReviewerCmd.Flags().String("reviewer", "", "Reviewer ID, email, or display name (required)")
+	prAddReviewerCmd.Flags().Bool("required", false, "Mark the reviewer as required")
+
+	// Remove-reviewer flags
+	addProjectFlags(prRemoveReviewerCmd)
+	prRemoveReviewerCmd.Flags().String("reviewer", "", "Reviewer ID, email, or display name (required)")
+
+	// Comment flags
+	addProjectFlags(prCommentCmd)
+	prCommentCmd.Flags().String("text", "", "Comment text (required)")
+	prCommentCmd.Flags().String("file", "", "Anchor the comment to this file path")
+	prCommentCmd.Flags().Int("line", 0, "Anchor the comment to this line (requires --file)")
+
+	// Threads flags
+	addProjectFlags(prThreadsCmd)
+	prThreadsCmd.Flags().Bool("unresolved-only", false, "Only show threads that are not resolved")
+
+	// Policies flags
+	addProjectFlags(prPoliciesCmd)
+
+	// Open flags
+	addProjectFlags(prOpenCmd)
+	prOpenCmd.Flags().Bool("print", false, "Print the URL instead of opening it")
+
+	// Work items flags
+	addProjectFlags(prWorkItemsCmd)
+	supportsTemplate(prWorkItemsCmd)
+
+	// Files flags
+	addProjectFlags(prFilesCmd)
+	prFilesCmd.Flags().Bool("name-only", false, "Print only the changed paths")
+
+	// Diff flags
+	addProjectFlags(prDiffCmd)
+	prDiffCmd.Flags().Bool("stat", false, "Show a summary of changed lines per file instead of the diff")
+	prDiffCmd.Flags().String("path", "", "Show only the diff of this file")
+
+	// Commits flags
+	addProjectFlags(prCommitsCmd)
+	supportsTemplate(prCommitsCmd)
+
+	// Update flags
+	addProjectFlags(prUpdateCmd)
+	prUpdateCmd.Flags().String("title", "", "New title")
+	prUpdateCmd.Flags().String("description", "", "New description (@file to read from a file, - for stdin, empty to clear)")
+
+	// Publish flags
+	addProjectFlags(prPublishCmd)
+
+	// Draft flags
+	addProjectFlags(prDraftCmd)
+
+	// Wait flags
+	addProjectFlags(prWaitCmd)
+	prWaitCmd.Flags().Bool("complete", false, "Set auto-complete before waiting")
+	addCompletionFlags(prWaitCmd)
 
 	prCmd.AddCommand(prListCmd)
 	prCmd.AddCommand(prShowCmd)
 	prCmd.AddCommand(prCreateCmd)
 	prCmd.AddCommand(prApproveCmd)
 	prCmd.AddCommand(prRejectCmd)
+	prCmd.AddCommand(prVoteCmd)
+	prCmd.AddCommand(prCompleteCmd)
+	prCmd.AddCommand(prAbandonCmd)
+	prCmd.AddCommand(prReactivateCmd)
+	prCmd.AddCommand(prAddReviewerCmd)
+	prCmd.AddCommand(prRemoveReviewerCmd)
+	prCmd.AddCommand(prCommentCmd)
+	prCmd.AddCommand(prThreadsCmd)
+	prCmd.AddCommand(prPoliciesCmd)
+	prCmd.AddCommand(prOpenCmd)
+	prCmd.AddCommand(prWorkItemsCmd)
+	prCmd.AddCommand(prFilesCmd)
+	prCmd.AddCommand(prDiffCmd)
+	prCmd.AddCommand(prCommitsCmd)
+	prCmd.AddCommand(prUpdateCmd)
+	prCmd.AddCommand(prPublishCmd)
+	prCmd.AddCommand(prDraftCmd)
+	prCmd.AddCommand(prWaitCmd)
 
 	rootCmd.AddCommand(prCmd)
 }
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var projectCmd = &cobra.Command{
+	Use:     "project",
+	Aliases: []string{"projects"},
+	Short:   "Manage projects",
+	Long:    "List the Azure DevOps projects in the organization.",
+}
+
+// --- ado project list ---
+
+var projectListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List projects",
+	RunE:  runProjectList,
+}
+
+func runProjectList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return strings.ToLower(projects[i].Name) < strings.ToLower(projects[j].Name)
+	})
+
+	if len(projects) == 0 {
+		if OutputFormat() == "json" {
+			return printJSON([]interface{}{})
+		} else {
+			fmt.Fprintln(os.Stderr, "No projects found.")
+		}
+		return nil
+	}
+
+	defer startPager()()
+
+	if ok, err := printTemplate(projects); ok {
+		return err
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(projects)
+	case "plain":
+		for _, p := range projects {
+			fmt.Printf("%s\t%s\t%s\n", p.ID, p.Name, p.State)
+		}
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{"ID", "Name", "State"})
+		for _, p := range projects {
+			_ = w.Write([]string{p.ID, p.Name, p.State})
+		}
+		w.Flush()
+		return w.Error()
+	default: // table
+		fmt.Fprintf(os.Stdout, "%-36s  %-40s %s\n", "ID", "Name", "State")
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", 95))
+		for _, p := range projects {
+			fmt.Fprintf(os.Stdout, "%-36s  %-40s %s\n", p.ID, truncate(p.Name, 40), p.State)
+		}
+	}
+	return nil
+}
+
+func init() {
+	supportsTemplate(projectListCmd)
+
+	projectCmd.AddCommand(projectListCmd)
+
+	rootCmd.AddCommand(projectCmd)
+}
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gyurisc/adocli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var repoCmd = &cobra.Command{
+	Use:     "repo",
+	Aliases: []string{"repos"},
+	Short:   "Manage Git repositories",
+	Long:    "List, show, delete, and restore Azure DevOps Git repositories.",
+}
+
+// --- ado repo list ---
+
+var repoListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List repositories",
+	RunE:  runRepoList,
+}
+
+func runRepoList(cmd *cobra.Command, args []string) error {
+	top, _ := cmd.Flags().GetInt("top")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	repos, err := client.ListRepositories(ctx, project)
+	if err != nil {
+		return fmt.Errorf("listing repositories: %w", err)
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		return strings.ToLower(repos[i].Name) < strings.ToLower(repos[j].Name)
+	})
+	if top > 0 && len(repos) > top {
+		repos = repos[:top]
+	}
+
+	if len(repos) == 0 {
+		if OutputFormat() == "json" {
+			return printJSON([]interface{}{})
+		} else {
+			fmt.Fprintln(os.Stderr, "No repositories found.")
+		}
+		return nil
+	}
+
+	defer startPager()()
+
+	if ok, err := printTemplate(repos); ok {
+		return err
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(repos)
+	case "plain":
+		for _, r := range repos {
+			fmt.Printf("%s\t%s\t%s\t%d\n", r.ID, r.Name, shortBranch(r.DefaultBranch), r.Size)
+		}
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{"ID", "Name", "DefaultBranch", "Size"})
+		for _, r := range repos {
+			_ = w.Write([]string{r.ID, r.Name, shortBranch(r.DefaultBranch), strconv.FormatInt(r.Size, 10)})
+		}
+		w.Flush()
+		return w.Error()
+	default: // table
+		fmt.Fprintf(os.Stdout, "%-36s  %-40s %-20s %10s\n", "ID", "Name", "Default Branch", "Size")
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", 110))
+		for _, r := range repos {
+			fmt.Fprintf(os.Stdout, "%-36s  %-40s %-20s %10s\n",
+				r.ID, truncate(r.Name, 40), truncate(shortBranch(r.DefaultBranch), 20), formatSize(r.Size))
+		}
+	}
+	return nil
+}
+
+// --- ado repo show ---
+
+var repoShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show repository details",
+	Long: `Show a repository's default branch, clone and web URLs, size, and whether
+it is disabled. JSON output is the full repository object from the API.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRepoShow,
+}
+
+func runRepoShow(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	repoID, err := resolveRepoID(ctx, client, project, args[0])
+	if err != nil {
+		return err
+	}
+
+	if OutputFormat() == "json" {
+		raw, err := client.GetRepositoryRaw(ctx, project, repoID)
+		if err != nil {
+			return fmt.Errorf("fetching repository %q: %w", args[0], err)
+		}
+		if ok, err := printTemplate(raw); ok {
+			return err
+		}
+		return printJSON(raw)
+	}
+
+	repo, err := client.GetRepository(ctx, project, repoID)
+	if err != nil {
+		return fmt.Errorf("fetching repository %q: %w", args[0], err)
+	}
+
+	if ok, err := printTemplate(repo); ok {
+		return err
+	}
+
+	switch OutputFormat() {
+	case "plain":
+		fmt.Printf("%s\t%s\t%s\t%s\t%d\t%t\n",
+			repo.ID, repo.Name, shortBranch(repo.DefaultBranch), repo.RemoteURL, repo.Size, repo.IsDisabled)
+	default:
+		fmt.Printf("Repository:     %s\n", repo.Name)
+		fmt.Printf("ID:             %s\n", repo.ID)
+		fmt.Printf("Default branch: %s\n", shortBranch(repo.DefaultBranch))
+		fmt.Printf("Size:           %s\n", formatSize(repo.Size))
+		fmt.Printf("Disabled:       %t\n", repo.IsDisabled)
+		fmt.Printf("Remote URL:     %s\n", repo.RemoteURL)
+		if repo.SSHURL != "" {
+			fmt.Printf("SSH URL:        %s\n", repo.SSHURL)
+		}
+		fmt.Printf("Web URL:        %s\n", repo.WebURL)
+	}
+	return nil
+}
+
+// --- ado repo delete ---
+
+var repoDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a repository",
+	Long: `Delete a Git repository. This is destructive: you are asked to confirm, and
+--yes is required when stdin is not a terminal.
+
+Azure DevOps keeps deleted repositories in the recycle bin for 30 days;
+use 'ado repo restore' to bring one back within that window.`,
+	RunE: runRepoDelete,
+}
+
+func runRepoDelete(cmd *cobra.Command, args []string) error {
+	repo, _ := cmd.Flags().GetString("repo")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	if repo == "" {
+		return fmt.Errorf("--repo is required")
+	}
+	if !yes {
+		if !isTTY(os.Stdin) {
+			return fmt.Errorf("refusing to delete repository %q without --yes", repo)
+		}
+		if !confirm(fmt.Sprintf("About to delete repository %s. Are you sure?", repo)) {
+			return errAborted
+		}
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	repoID, err := resolveRepoID(ctx, client, project, repo)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteRepository(ctx, project, repoID); err != nil {
+		return fmt.Errorf("deleting repository %q: %w", repo, err)
+	}
+
+	switch OutputFormat() {
+	case "json":
+		out := map[string]interface{}{
+			"id":      repoID,
+			"name":    repo,
+			"deleted": true,
+		}
+		return printJSON(out)
+	case "plain":
+		fmt.Printf("%s\t%s\n", repoID, repo)
+	default:
+		fmt.Printf("Deleted repository %s (%s)\n", repo, repoID)
+	}
+	return nil
+}
+
+// --- ado repo restore ---
+
+var repoRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a deleted repository",
+	Long:  "Restore a soft-deleted repository from the project recycle bin.",
+	RunE:  runRepoRestore,
+}
+
+func runRepoRestore(cmd *cobra.Command, args []string) error {
+	repo, _ := cmd.Flags().GetString("repo")
+	if repo == "" {
+		return fmt.Errorf("--repo is required")
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	repoID, err := resolveDeletedRepoID(ctx, client, project, repo)
+	if err != nil {
+		return err
+	}
+
+	restored, err := client.RestoreRepository(ctx, project, repoID)
+	if err != nil {
+		return fmt.Errorf("restoring repository %q: %w", repo, err)
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(restored)
+	case "plain":
+		fmt.Printf("%s\t%s\n", restored.ID, restored.Name)
+	default:
+		fmt.Printf("Restored repository %s (%s)\n", restored.Name, restored.ID)
+	}
+	return nil
+}
+
+// --- helpers ---
+
+// formatSize renders a byte count with a binary unit, e.g. "12.3 MB".
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// resolveDeletedRepoID maps a repository name (or ID) in the recycle bin to its ID.
+func resolveDeletedRepoID(ctx context.Context, client *api.Client, project, repoName string) (string, error) {
+	repos, err := client.ListDeletedRepositories(ctx, project)
+	if err != nil {
+		return "", fmt.Errorf("listing deleted repositories: %w", err)
+	}
+	for _, r := range repos {
+		if strings.EqualFold(r.Name, repoName) || strings.EqualFold(r.ID, repoName) {
+			return r.ID, nil
+		}
+	}
+	return "", fmt.Errorf("deleted repository %q not found in project %q", repoName, project)
+}
+
+func init() {
+	// List flags
+	addProjectFlags(repoListCmd)
+	supportsTemplate(repoListCmd)
+	repoListCmd.Flags().Int("top", 0, "Maximum number of results (0 for all)")
+
+	// Show flags
+	addProjectFlags(repoShowCmd)
+	supportsTemplate(repoShowCmd)
+	repoShowCmd.ValidArgsFunction = completeRepos
+
+	// Delete flags
+	addProjectFlags(repoDeleteCmd)
+	repoDeleteCmd.Flags().String("repo", "", "Repository name or ID (required)")
+	_ = repoDeleteCmd.RegisterFlagCompletionFunc("repo", completeRepos)
+	repoDeleteCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+
+	// Restore flags
+	addProjectFlags(repoRestoreCmd)
+	repoRestoreCmd.Flags().String("repo", "", "Repository name or ID (required)")
+
+	repoCmd.AddCommand(repoListCmd)
+	repoCmd.AddCommand(repoShowCmd)
+	repoCmd.AddCommand(repoDeleteCmd)
+	repoCmd.AddCommand(repoRestoreCmd)
+
+	rootCmd.AddCommand(repoCmd)
+}
@@ -2,17 +2,33 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/gyurisc/adocli/internal/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	jsonOutput  bool
-	plainOutput bool
-	appVersion  string
+	outputFlag   string
+	jsonOutput   bool
+	plainOutput  bool
+	csvOutput    bool
+	verbose      bool
+	orgFlag      string
+	templateFlag string
+	queryFlag    string
+	noPager      bool
+	appVersion   string
+
+	requestTimeout time.Duration
+	cancelTimeout  context.CancelFunc = func() {}
 )
 
 // SetVersion sets the application version (called from main with ldflags value).
@@ -21,15 +37,34 @@ func SetVersion(v string) {
 	rootCmd.Version = v
 }
 
+// debugEnabled reports whether API requests should be logged to stderr,
+// via --verbose or ADO_DEBUG=1.
+func debugEnabled() bool {
+	return verbose || os.Getenv("ADO_DEBUG") == "1"
+}
+
+// outputFormats are the values accepted by --output and output_format.
+var outputFormats = []string{"table", "json", "plain", "csv"}
+
 // OutputFormat returns the current output format based on flags.
-// Priority: --json > --plain > config > "table" (default).
+// Priority: --output > --query (implies json) > --json > --plain > --csv >
+// config > "table" (default).
 func OutputFormat() string {
+	if outputFlag != "" {
+		return outputFlag
+	}
+	if queryFlag != "" {
+		return "json" // --query filters JSON output
+	}
 	if jsonOutput {
 		return "json"
 	}
 	if plainOutput {
 		return "plain"
 	}
+	if csvOutput {
+		return "csv"
+	}
 	if f := viper.GetString("output_format"); f != "" {
 		return f
 	}
@@ -44,21 +79,77 @@ It supports work items, pull requests, pipelines, repos, and more.
 
 Configure with: ado auth login
 Config file:    ~/.config/ado/config.json`,
-	SilenceUsage: true,
+	SilenceUsage:  true,
+	SilenceErrors: true, // printed by Execute
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if outputFlag != "" && !slices.Contains(outputFormats, outputFlag) {
+			return fmt.Errorf("invalid --output %q (must be %s)", outputFlag, strings.Join(outputFormats, ", "))
+		}
+		if err := checkTemplateSupport(cmd); err != nil {
+			return err
+		}
+
+		// Give the whole command a deadline; API calls share cmd.Context().
+		if requestTimeout > 0 {
+			var ctx context.Context
+			ctx, cancelTimeout = context.WithTimeout(cmd.Context(), requestTimeout)
+			cmd.SetContext(ctx)
+		}
+		return nil
+	},
 }
 
-// Execute runs the root command.
+// errNoResults is returned by list commands run with --exit-code when nothing
+// matched. Execute exits 1 without printing it, like grep -q.
+var errNoResults = errors.New("no results")
+
+// Execute runs the root command. Any error, including API failures from
+// mutating commands, makes the process exit with status 1.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	err := rootCmd.ExecuteContext(context.Background())
+	cancelTimeout()
+	if code := reportError(err); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// reportError prints err the way Execute does and returns the exit status
+// for it: 0 for nil, otherwise 1. errNoResults is not printed. Output goes to
+// rootCmd's streams so it can be captured.
+func reportError(err error) int {
+	if err == nil {
+		return 0
+	}
+	if !errors.Is(err, errNoResults) {
+		rootCmd.PrintErrln(rootCmd.ErrPrefix(), err.Error())
+		var written *resultWrittenError
+		if OutputFormat() == "json" && !errors.As(err, &written) {
+			printJSONError(rootCmd.OutOrStdout(), err)
+		}
+	}
+	var apiErr *api.Error
+	if debugEnabled() && errors.As(err, &apiErr) {
+		fmt.Fprintf(rootCmd.ErrOrStderr(), "Response (HTTP %d):\n%s\n", apiErr.StatusCode, apiErr.Body)
 	}
+	return 1
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "Output format: table, json, plain, or csv")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	rootCmd.PersistentFlags().BoolVar(&plainOutput, "plain", false, "Output in plain text (no colors, no borders)")
+	rootCmd.PersistentFlags().BoolVar(&csvOutput, "csv", false, "Output list results as CSV")
+	rootCmd.PersistentFlags().StringVar(&templateFlag, "template", "", `Format each result with a Go template, e.g. '{{.ID}} {{field .Fields "System.Title"}}'`)
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "Do not pipe long output through $PAGER")
+	rootCmd.PersistentFlags().StringVar(&queryFlag, "query", "", `JMESPath query applied to JSON output, e.g. '[].fields."System.Title"'`)
+	_ = rootCmd.PersistentFlags().MarkDeprecated("json", "use --output json")
+	_ = rootCmd.PersistentFlags().MarkDeprecated("plain", "use --output plain")
+	_ = rootCmd.PersistentFlags().MarkDeprecated("csv", "use --output csv")
+	rootCmd.PersistentFlags().StringVar(&orgFlag, "org", "", "Azure DevOps organization (overrides config, profile, and ADO_ORGANIZATION)")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Log API requests to stderr and show full error responses (or set ADO_DEBUG=1)")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "timeout", 0, "Abort if the command takes longer than this, e.g. 30s (0 means no limit)")
 
 	rootCmd.SetVersionTemplate(fmt.Sprintf("ado version %s\n", appVersion))
 }
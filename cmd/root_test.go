@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gyurisc/adocli/internal/api"
+)
+
+func TestReportError(t *testing.T) {
+	unauthorized := &api.Error{StatusCode: 401, Message: "TF400813: not authorized"}
+	notFound := &api.Error{StatusCode: 404, Message: "TF401019: repository not found", TypeKey: "GitRepositoryNotFoundException"}
+
+	tests := []struct {
+		name       string
+		err        error
+		output     string
+		wantCode   int
+		wantStderr string
+		wantStatus int // statusCode in the JSON error document; 0 means no document
+	}{
+		{"success", nil, "table", 0, "", 0},
+		{"no results", errNoResults, "table", 1, "", 0},
+		{"no results in json mode", errNoResults, "json", 1, "", 0},
+		{"plain error", errors.New("--title is required"), "table", 1, "--title is required", 0},
+		{"api 401", fmt.Errorf("listing projects: %w", unauthorized), "table", 1, "not authorized", 0},
+		{"api 404", fmt.Errorf("fetching repository: %w", notFound), "table", 1, "repository not found", 0},
+		{"api 401 in json mode", fmt.Errorf("listing projects: %w", unauthorized), "json", 1, "not authorized", 401},
+		{"api 404 in json mode", fmt.Errorf("fetching repository: %w", notFound), "json", 1, "repository not found", 404},
+		{"error after json result", afterResult(errors.New("2 of 5 work items failed")), "json", 1, "2 of 5 work items failed", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			rootCmd.SetOut(&stdout)
+			rootCmd.SetErr(&stderr)
+			outputFlag = tt.output
+			t.Cleanup(func() {
+				rootCmd.SetOut(nil)
+				rootCmd.SetErr(nil)
+				outputFlag = ""
+			})
+
+			if code := reportError(tt.err); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+
+			if tt.wantStderr == "" {
+				if stderr.Len() != 0 {
+					t.Errorf("stderr = %q, want nothing", stderr.String())
+				}
+			} else if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+
+			if tt.wantStatus == 0 {
+				if stdout.Len() != 0 {
+					t.Errorf("stdout = %q, want nothing", stdout.String())
+				}
+				return
+			}
+			var doc map[string]jsonError
+			if err := json.Unmarshal(stdout.Bytes(), &doc); err != nil {
+				t.Fatalf("stdout is not a JSON document: %v\n%s", err, stdout.String())
+			}
+			if got := doc["error"].StatusCode; got != tt.wantStatus {
+				t.Errorf("error.statusCode = %d, want %d", got, tt.wantStatus)
+			}
+		})
+	}
+}
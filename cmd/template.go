@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// templateFuncs are available in --template in addition to the builtins.
+var templateFuncs = template.FuncMap{
+	// field looks up a dotted work item field name, e.g. {{field .Fields "System.Title"}}.
+	"field": func(fields map[string]interface{}, name string) string {
+		return fieldStr(fields, name)
+	},
+}
+
+// templateAnnotation marks commands whose output honors --template.
+const templateAnnotation = "supportsTemplate"
+
+// supportsTemplate marks cmd as rendering its results with printTemplate.
+// --template on any other command is rejected rather than ignored.
+func supportsTemplate(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[templateAnnotation] = "true"
+}
+
+// checkTemplateSupport returns an error if --template was given to a command
+// that does not use it.
+func checkTemplateSupport(cmd *cobra.Command) error {
+	if templateFlag != "" && cmd.Annotations[templateAnnotation] == "" {
+		return fmt.Errorf("--template is not supported by '%s'", cmd.CommandPath())
+	}
+	return nil
+}
+
+// printTemplate renders v with the --template flag, once per element when v
+// is a slice. It reports false if no template was given.
+//
+// Templates see the same structs as JSON output, so struct field names are
+// used: {{.ID}} {{field .Fields "System.Title"}} or {{.Title}} for PRs.
+func printTemplate(v interface{}) (bool, error) {
+	if templateFlag == "" {
+		return false, nil
+	}
+	tmpl, err := template.New("template").Funcs(templateFuncs).Option("missingkey=error").Parse(templateFlag)
+	if err != nil {
+		return true, fmt.Errorf("parsing --template: %w", err)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return true, executeTemplate(tmpl, v)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := executeTemplate(tmpl, rv.Index(i).Interface()); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// executeTemplate writes one rendered item followed by a newline. Execution
+// errors name the failing expression, e.g. `at <.Titel>`.
+func executeTemplate(tmpl *template.Template, v interface{}) error {
+	if err := tmpl.Execute(os.Stdout, v); err != nil {
+		return fmt.Errorf("executing --template: %w", err)
+	}
+	fmt.Fprintln(os.Stdout)
+	return nil
+}
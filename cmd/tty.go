@@ -0,0 +1,16 @@
+package cmd
+
+import "os"
+
+// isTTY reports whether f is an interactive terminal. Output routines use it
+// to keep escape codes, prompts, and paging out of pipes and log files.
+func isTTY(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// noColor reports whether the user opted out of color via the NO_COLOR
+// convention (https://no-color.org): any non-empty value disables color.
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
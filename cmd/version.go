@@ -1,9 +1,7 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"runtime"
 
 	"github.com/spf13/cobra"
@@ -29,9 +27,7 @@ var versionCmd = &cobra.Command{
 
 		switch OutputFormat() {
 		case "json":
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
-			return enc.Encode(info)
+			return printJSON(info)
 		default:
 			fmt.Printf("ado %s (%s/%s, %s)\n", info.Version, info.OS, info.Arch, info.GoVer)
 			return nil
@@ -1,11 +1,21 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gyurisc/adocli/internal/api"
 	"github.com/spf13/cobra"
@@ -14,7 +24,7 @@ import (
 
 // newAPIClient creates a new API client using config and keyring.
 func newAPIClient() (*api.Client, error) {
-	org := viper.GetString("organization")
+	org := defaultOrganization()
 	if org == "" {
 		return nil, fmt.Errorf("organization not configured (run 'ado config set organization <org>')")
 	}
@@ -22,22 +32,61 @@ func newAPIClient() (*api.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return api.NewClient(org, pat), nil
+	return newClientWithPAT(org, pat), nil
+}
+
+// newClientWithPAT creates an API client for org with an explicit PAT.
+func newClientWithPAT(org, pat string) *api.Client {
+	client := api.NewClient(org, pat)
+	client.UserAgent = "adocli/" + appVersion
+	if v := viper.GetString("api_version"); v != "" {
+		client.APIVersion = v
+	}
+	if viper.IsSet("rate_limit") {
+		// A malformed value must not turn into 0, which disables the limiter.
+		value := viper.GetString("rate_limit")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate < 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring invalid rate_limit %q (want requests per second, 0 for no limit); using the default of %g\n", value, client.RateLimit)
+		} else {
+			client.RateLimit = rate
+		}
+	}
+	if debugEnabled() {
+		client.Debug = os.Stderr
+	}
+	return client
 }
 
 // resolveProject returns the project from the flag or config default.
+// Either a project name (--project) or a project GUID (--project-id) is accepted;
+// the API treats both the same way in project-scoped URLs.
 func resolveProject(cmd *cobra.Command) (string, error) {
+	if id, _ := cmd.Flags().GetString("project-id"); id != "" {
+		if !api.IsGUID(id) {
+			return "", fmt.Errorf("invalid project ID %q (expected a GUID)", id)
+		}
+		return id, nil
+	}
 	p, _ := cmd.Flags().GetString("project")
 	if p != "" {
 		return p, nil
 	}
-	p = viper.GetString("project")
+	p = defaultProject()
 	if p == "" {
 		return "", fmt.Errorf("project not specified (use --project or 'ado config set project <name>')")
 	}
 	return p, nil
 }
 
+// addProjectFlags registers the --project and --project-id flags on a command.
+func addProjectFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("project", "p", "", "Project name or ID")
+	cmd.Flags().String("project-id", "", "Project ID (GUID), alternative to --project")
+	cmd.MarkFlagsMutuallyExclusive("project", "project-id")
+	_ = cmd.RegisterFlagCompletionFunc("project", completeProjects)
+}
+
 var workitemCmd = &cobra.Command{
 	Use:     "workitem",
 	Aliases: []string{"wi"},
@@ -54,38 +103,119 @@ var wiListCmd = &cobra.Command{
 	RunE:  runWorkitemList,
 }
 
+// unassigned is the special --assigned-to value that matches work items with no owner.
+const unassigned = "unassigned"
+
+// currentIterationRe matches the @currentIteration macro with an optional
+// offset, e.g. "@currentIteration - 1". Only values matching it are put into
+// WIQL unquoted.
+var currentIterationRe = regexp.MustCompile(`^@currentIteration(\s*[+-]\s*\d+)?$`)
+
 // escapeWIQL escapes single quotes in a string for safe WIQL interpolation.
 func escapeWIQL(s string) string {
 	return strings.ReplaceAll(s, "'", "''")
 }
 
-func buildWIQL(project, wiType, state, assignedTo string) string {
-	q := "SELECT [System.Id], [System.Title], [System.State], [System.WorkItemType], [System.AssignedTo] FROM WorkItems"
+// workItemFilter holds the 'workitem list' filters that buildWIQL turns into conditions.
+type workItemFilter struct {
+	Type          string
+	State         string
+	AssignedTo    string
+	AreaPath      string
+	IterationPath string
+	CreatedBy     string
+	CreatedAfter  string // YYYY-MM-DD
+	ChangedAfter  string // YYYY-MM-DD
+	ExcludeStates []string
+	Sort          string // field reference name; empty means System.ChangedDate
+	Order         string // "asc" or "desc"
+	Top           int    // maximum number of results; 0 means no limit
+}
+
+// buildWIQL turns a filter into a WIQL query. A positive Top becomes a TOP
+// clause; callers pass the same value to QueryByWiql, which sends it as $top,
+// so the query text and the request agree on the cap.
+func buildWIQL(project string, f workItemFilter) string {
+	q := "SELECT "
+	if f.Top > 0 {
+		q += fmt.Sprintf("TOP %d ", f.Top)
+	}
+	q += "[System.Id], [System.Title], [System.State], [System.WorkItemType], [System.AssignedTo] FROM WorkItems"
 
 	var conditions []string
-	conditions = append(conditions, fmt.Sprintf("[System.TeamProject] = '%s'", escapeWIQL(project)))
+	if api.IsGUID(project) {
+		// TeamProject compares against names; the query already runs in the
+		// project's scope, so @project resolves the ID for us.
+		conditions = append(conditions, "[System.TeamProject] = @project")
+	} else {
+		conditions = append(conditions, fmt.Sprintf("[System.TeamProject] = '%s'", escapeWIQL(project)))
+	}
 
-	if wiType != "" {
-		conditions = append(conditions, fmt.Sprintf("[System.WorkItemType] = '%s'", escapeWIQL(wiType)))
+	if f.Type != "" {
+		conditions = append(conditions, fmt.Sprintf("[System.WorkItemType] = '%s'", escapeWIQL(f.Type)))
 	}
-	if state != "" {
-		conditions = append(conditions, fmt.Sprintf("[System.State] = '%s'", escapeWIQL(state)))
+	if f.State != "" {
+		conditions = append(conditions, fmt.Sprintf("[System.State] = '%s'", escapeWIQL(f.State)))
 	}
-	if assignedTo != "" {
-		if assignedTo == "@me" {
+	if len(f.ExcludeStates) > 0 {
+		quoted := make([]string, len(f.ExcludeStates))
+		for i, st := range f.ExcludeStates {
+			quoted[i] = fmt.Sprintf("'%s'", escapeWIQL(st))
+		}
+		conditions = append(conditions, fmt.Sprintf("[System.State] NOT IN (%s)", strings.Join(quoted, ", ")))
+	}
+	if f.AssignedTo != "" {
+		switch {
+		case f.AssignedTo == "@me":
 			conditions = append(conditions, "[System.AssignedTo] = @me")
+		case strings.EqualFold(f.AssignedTo, unassigned):
+			conditions = append(conditions, "[System.AssignedTo] = ''")
+		default:
+			conditions = append(conditions, fmt.Sprintf("[System.AssignedTo] = '%s'", escapeWIQL(f.AssignedTo)))
+		}
+	}
+	if f.CreatedBy != "" {
+		if f.CreatedBy == "@me" {
+			conditions = append(conditions, "[System.CreatedBy] = @me")
+		} else {
+			conditions = append(conditions, fmt.Sprintf("[System.CreatedBy] = '%s'", escapeWIQL(f.CreatedBy)))
+		}
+	}
+	if f.CreatedAfter != "" {
+		conditions = append(conditions, fmt.Sprintf("[System.CreatedDate] >= '%s'", f.CreatedAfter))
+	}
+	if f.ChangedAfter != "" {
+		conditions = append(conditions, fmt.Sprintf("[System.ChangedDate] >= '%s'", f.ChangedAfter))
+	}
+	// UNDER includes child paths, which is what users expect for area/iteration filters.
+	if f.AreaPath != "" {
+		conditions = append(conditions, fmt.Sprintf("[System.AreaPath] UNDER '%s'", escapeWIQL(f.AreaPath)))
+	}
+	if f.IterationPath != "" {
+		if currentIterationRe.MatchString(f.IterationPath) {
+			conditions = append(conditions, "[System.IterationPath] = "+f.IterationPath)
 		} else {
-			conditions = append(conditions, fmt.Sprintf("[System.AssignedTo] = '%s'", escapeWIQL(assignedTo)))
+			conditions = append(conditions, fmt.Sprintf("[System.IterationPath] UNDER '%s'", escapeWIQL(f.IterationPath)))
 		}
 	}
 
+	sortField := f.Sort
+	if sortField == "" {
+		sortField = "System.ChangedDate"
+	}
+	order := "DESC"
+	if strings.EqualFold(f.Order, "asc") {
+		order = "ASC"
+	}
+
 	q += " WHERE " + strings.Join(conditions, " AND ")
-	q += " ORDER BY [System.ChangedDate] DESC"
+	q += fmt.Sprintf(" ORDER BY [%s] %s", sortField, order)
 
 	return q
 }
 
 func runWorkitemList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	client, err := newAPIClient()
 	if err != nil {
 		return err
@@ -95,25 +225,61 @@ func runWorkitemList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	wiType, _ := cmd.Flags().GetString("type")
-	state, _ := cmd.Flags().GetString("state")
-	assignedTo, _ := cmd.Flags().GetString("assigned-to")
+	var f workItemFilter
+	f.Type, _ = cmd.Flags().GetString("type")
+	f.State, _ = cmd.Flags().GetString("state")
+	f.AssignedTo, _ = cmd.Flags().GetString("assigned-to")
+	f.AreaPath, _ = cmd.Flags().GetString("area-path")
+	f.IterationPath, _ = cmd.Flags().GetString("iteration-path")
+	if strings.HasPrefix(f.IterationPath, "@") && !currentIterationRe.MatchString(f.IterationPath) {
+		return fmt.Errorf("invalid --iteration-path %q (use @currentIteration, optionally with an offset like \"@currentIteration - 1\")", f.IterationPath)
+	}
+	f.CreatedBy, _ = cmd.Flags().GetString("created-by")
+	f.Order, _ = cmd.Flags().GetString("order")
 	top, _ := cmd.Flags().GetInt("top")
 
-	wiql := buildWIQL(project, wiType, state, assignedTo)
+	if after, _ := cmd.Flags().GetString("created-after"); after != "" {
+		if f.CreatedAfter, err = parseDate(after, time.Now()); err != nil {
+			return fmt.Errorf("invalid --created-after: %w", err)
+		}
+	}
+	if after, _ := cmd.Flags().GetString("changed-after"); after != "" {
+		if f.ChangedAfter, err = parseDate(after, time.Now()); err != nil {
+			return fmt.Errorf("invalid --changed-after: %w", err)
+		}
+	}
 
-	result, err := client.QueryByWiql(project, wiql, top)
-	if err != nil {
-		return fmt.Errorf("querying work items: %w", err)
+	if sort, _ := cmd.Flags().GetString("sort"); sort != "" {
+		sort = strings.TrimSuffix(strings.TrimPrefix(sort, "["), "]")
+		if strings.ContainsAny(sort, "[]' ") {
+			return fmt.Errorf("invalid --sort field %q", sort)
+		}
+		if !strings.Contains(sort, ".") {
+			sort = "System." + sort
+		}
+		f.Sort = sort
+	}
+	f.Order = strings.ToLower(f.Order)
+	if f.Order != "asc" && f.Order != "desc" {
+		return fmt.Errorf("invalid --order %q (must be asc or desc)", f.Order)
 	}
 
-	if len(result.WorkItems) == 0 {
-		if OutputFormat() == "json" {
-			fmt.Println("[]")
-		} else {
-			fmt.Fprintln(os.Stderr, "No work items found.")
+	if onlyUnassigned, _ := cmd.Flags().GetBool("assignee-unassigned"); onlyUnassigned {
+		if f.AssignedTo != "" && !strings.EqualFold(f.AssignedTo, unassigned) {
+			return fmt.Errorf("--assignee-unassigned cannot be combined with --assigned-to %q", f.AssignedTo)
 		}
-		return nil
+		f.AssignedTo = unassigned
+	}
+
+	withCount, _ := cmd.Flags().GetBool("count")
+	if withCount && (OutputFormat() != "json" || templateFlag != "") {
+		return fmt.Errorf("--count requires JSON output (-o json) and cannot be combined with --template")
+	}
+
+	f.Top = top
+	result, err := client.QueryByWiql(ctx, project, buildWIQL(project, f), top)
+	if err != nil {
+		return fmt.Errorf("querying work items: %w", err)
 	}
 
 	// Collect IDs, respecting --top.
@@ -125,35 +291,265 @@ func runWorkitemList(cmd *cobra.Command, args []string) error {
 		ids = ids[:top]
 	}
 
-	items, err := client.GetWorkItems(project, ids)
+	// Only the fields behind the shown columns are fetched, which keeps
+	// responses small; this includes the default columns.
+	columns := slices.Clone(defaultWorkItemColumns)
+	if spec, _ := cmd.Flags().GetString("fields"); spec != "" {
+		columns = parseWorkItemColumns(spec)
+	}
+	showPoints, _ := cmd.Flags().GetBool("show-points")
+	showPriority, _ := cmd.Flags().GetBool("show-priority")
+	if showPoints {
+		columns = append(columns, workItemColumn{"Microsoft.VSTS.Scheduling.StoryPoints", "Points", 6})
+	}
+	if showPriority {
+		columns = append(columns, workItemColumn{"Microsoft.VSTS.Common.Priority", "Pri", 3})
+	}
+	if withCount {
+		total, err := countWorkItems(ctx, client, project, f, len(ids))
+		if err != nil {
+			return err
+		}
+		items, err := fetchWorkItems(ctx, client, project, ids, columns)
+		if err != nil {
+			return err
+		}
+		if items == nil {
+			items = []api.WorkItem{}
+		}
+		if err := printJSON(workItemPage{TotalCount: total, Count: len(items), Value: items}); err != nil {
+			return err
+		}
+	} else if err := fetchAndPrintWorkItems(ctx, client, project, ids, columns); err != nil {
+		return err
+	}
+	if OutputFormat() == "table" && top > 0 && len(ids) == top {
+		// The result may have been cut off at --top; count everything.
+		if total, err := countWorkItems(ctx, client, project, f, len(ids)); err != nil {
+			fmt.Fprintf(os.Stderr, "Showing %d (could not count all matches: %v)\n", len(ids), err)
+		} else if total > len(ids) {
+			fmt.Fprintf(os.Stderr, "Showing %d of %d (use --top to see more)\n", len(ids), total)
+		}
+	}
+	if exitCode, _ := cmd.Flags().GetBool("exit-code"); exitCode && len(ids) == 0 {
+		return errNoResults
+	}
+	return nil
+}
+
+// countWorkItems returns how many work items match f, ignoring its Top. shown
+// is the number already fetched; if it is under the cap nothing was cut off
+// and no second query is needed. Otherwise an uncapped query is run, which
+// returns only IDs and is subject to the server's 20,000 result limit.
+func countWorkItems(ctx context.Context, client *api.Client, project string, f workItemFilter, shown int) (int, error) {
+	if f.Top <= 0 || shown < f.Top {
+		return shown, nil
+	}
+	f.Top = 0
+	result, err := client.QueryByWiql(ctx, project, buildWIQL(project, f), 0)
+	if err != nil {
+		return 0, fmt.Errorf("counting work items: %w", err)
+	}
+	return len(result.WorkItems), nil
+}
+
+// workItemPage is the JSON output of 'workitem list --count'. TotalCount is
+// the number of matching work items before --top was applied.
+type workItemPage struct {
+	TotalCount int            `json:"totalCount"`
+	Count      int            `json:"count"`
+	Value      []api.WorkItem `json:"value"`
+}
+
+// workItemColumn is a field shown as a column in work item tables.
+type workItemColumn struct {
+	Field  string
+	Header string
+	Width  int
+}
+
+// defaultWorkItemColumns are the columns shown by list commands when --fields is not given.
+var defaultWorkItemColumns = []workItemColumn{
+	{"System.WorkItemType", "Type", 16},
+	{"System.Title", "Title", 50},
+	{"System.State", "State", 12},
+	{"System.AssignedTo", "Assigned To", 20},
+}
+
+// parseWorkItemColumns builds table columns from a --fields value. Bare names
+// are taken to be System fields; System.Id is implied, as ID is always shown.
+func parseWorkItemColumns(spec string) []workItemColumn {
+	var cols []workItemColumn
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !strings.Contains(name, ".") {
+			name = "System." + name
+		}
+		if strings.EqualFold(name, "System.Id") {
+			continue
+		}
+		col := workItemColumn{Field: name, Header: name[strings.LastIndex(name, ".")+1:], Width: 20}
+		for _, d := range defaultWorkItemColumns {
+			if strings.EqualFold(d.Field, name) {
+				col = d
+			}
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// fetchWorkItems gets the work items with the given IDs. If columns is
+// non-nil only the fields they show (plus ID and title) are fetched.
+func fetchWorkItems(ctx context.Context, client *api.Client, project string, ids []int, columns []workItemColumn) ([]api.WorkItem, error) {
+	var opts api.WorkItemsOptions
+	if columns != nil {
+		// Title is always fetched for plain output.
+		opts.Fields = []string{"System.Id", "System.Title"}
+		for _, c := range columns {
+			if c.Field != "System.Title" {
+				opts.Fields = append(opts.Fields, c.Field)
+			}
+		}
+	}
+
+	items, err := client.GetWorkItemsWithOptions(ctx, project, ids, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetching work items: %w", err)
+	}
+	return items, nil
+}
+
+// fetchAndPrintWorkItems batch-fetches the given work items and renders them
+// in the current output format. When columns is nil the default columns are
+// shown and all fields are fetched; otherwise only the column fields are fetched.
+func fetchAndPrintWorkItems(ctx context.Context, client *api.Client, project string, ids []int, columns []workItemColumn) error {
+	if len(ids) == 0 {
+		if OutputFormat() == "json" {
+			return printJSON([]interface{}{})
+		} else {
+			fmt.Fprintln(os.Stderr, "No work items found.")
+		}
+		return nil
+	}
+
+	items, err := fetchWorkItems(ctx, client, project, ids, columns)
 	if err != nil {
-		return fmt.Errorf("fetching work items: %w", err)
+		return err
+	}
+	if columns == nil {
+		columns = defaultWorkItemColumns
+	}
+
+	defer startPager()()
+
+	if ok, err := printTemplate(items); ok {
+		return err
 	}
 
 	switch OutputFormat() {
 	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(items)
+		return printJSON(items)
 	case "plain":
 		for _, wi := range items {
 			title, _ := wi.Fields["System.Title"].(string)
 			fmt.Printf("%d\t%s\n", wi.ID, title)
 		}
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		header := []string{"ID"}
+		for _, c := range columns {
+			header = append(header, c.Header)
+		}
+		_ = w.Write(header)
+		for _, wi := range items {
+			row := []string{strconv.Itoa(wi.ID)}
+			for _, c := range columns {
+				row = append(row, fieldStr(wi.Fields, c.Field))
+			}
+			_ = w.Write(row)
+		}
+		w.Flush()
+		return w.Error()
 	default: // table
-		fmt.Fprintf(os.Stdout, "%-8s %-16s %-50s %-12s %-20s\n", "ID", "Type", "Title", "State", "Assigned To")
-		fmt.Fprintln(os.Stdout, strings.Repeat("-", 110))
+		var header strings.Builder
+		fmt.Fprintf(&header, "%-8s", "ID")
+		width := 8
+		for _, c := range columns {
+			fmt.Fprintf(&header, " %-*s", c.Width, c.Header)
+			width += 1 + c.Width
+		}
+		fmt.Fprintln(os.Stdout, header.String())
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", width))
 		for _, wi := range items {
-			title := truncate(fieldStr(wi.Fields, "System.Title"), 50)
-			wiT := fieldStr(wi.Fields, "System.WorkItemType")
-			st := fieldStr(wi.Fields, "System.State")
-			assigned := truncate(fieldStr(wi.Fields, "System.AssignedTo"), 20)
-			fmt.Fprintf(os.Stdout, "%-8d %-16s %-50s %-12s %-20s\n", wi.ID, wiT, title, st, assigned)
+			var row strings.Builder
+			fmt.Fprintf(&row, "%-8d", wi.ID)
+			for _, c := range columns {
+				value := fieldStr(wi.Fields, c.Field)
+				cell := fmt.Sprintf("%-*s", c.Width, truncate(value, c.Width))
+				if c.Field == "System.State" {
+					cell = colorState(cell, value)
+				}
+				row.WriteString(" " + cell)
+			}
+			fmt.Fprintln(os.Stdout, row.String())
 		}
 	}
 	return nil
 }
 
+// --- ado workitem mine ---
+
+// doneStates are the closed-out states hidden by 'workitem mine' across the
+// standard process templates.
+var doneStates = []string{"Closed", "Done", "Removed"}
+
+var wiMineCmd = &cobra.Command{
+	Use:   "mine",
+	Short: "List work items assigned to you",
+	Long: `List work items assigned to the authenticated user.
+
+Closed, Done, and Removed items are hidden unless --all-states or --state is given.`,
+	RunE: runWorkitemMine,
+}
+
+func runWorkitemMine(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	f := workItemFilter{AssignedTo: "@me", Order: "desc"}
+	f.Type, _ = cmd.Flags().GetString("type")
+	f.State, _ = cmd.Flags().GetString("state")
+	allStates, _ := cmd.Flags().GetBool("all-states")
+	top, _ := cmd.Flags().GetInt("top")
+	f.Top = top
+
+	if f.State == "" && !allStates {
+		f.ExcludeStates = doneStates
+	}
+
+	result, err := client.QueryByWiql(ctx, project, buildWIQL(project, f), top)
+	if err != nil {
+		return fmt.Errorf("querying work items: %w", err)
+	}
+
+	ids := result.IDs()
+	if top > 0 && len(ids) > top {
+		ids = ids[:top]
+	}
+	return fetchAndPrintWorkItems(ctx, client, project, ids, nil)
+}
+
 // --- ado workitem show ---
 
 var wiShowCmd = &cobra.Command{
@@ -170,6 +566,7 @@ func runWorkitemShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid work item ID: %s", args[0])
 	}
 
+	ctx := cmd.Context()
 	client, err := newAPIClient()
 	if err != nil {
 		return err
@@ -180,16 +577,20 @@ func runWorkitemShow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	wi, err := client.GetWorkItem(project, id)
+	wi, err := client.GetWorkItem(ctx, project, id, "relations")
 	if err != nil {
 		return fmt.Errorf("fetching work item %d: %w", id, err)
 	}
 
+	defer startPager()()
+
+	if ok, err := printTemplate(wi); ok {
+		return err
+	}
+
 	switch OutputFormat() {
 	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(wi)
+		return printJSON(wi)
 	case "plain":
 		title := fieldStr(wi.Fields, "System.Title")
 		fmt.Printf("%d\t%s\n", wi.ID, title)
@@ -205,6 +606,12 @@ func runWorkitemShow(cmd *cobra.Command, args []string) error {
 		if desc != "" {
 			fmt.Printf("\nDescription:\n%s\n", desc)
 		}
+		if len(wi.Relations) > 0 {
+			fmt.Println("\nLinks:")
+			for _, rel := range wi.Relations {
+				fmt.Printf("  - %s\n", relationString(rel))
+			}
+		}
 	}
 	return nil
 }
@@ -219,6 +626,7 @@ var wiCreateCmd = &cobra.Command{
 }
 
 func runWorkitemCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	client, err := newAPIClient()
 	if err != nil {
 		return err
@@ -234,6 +642,7 @@ func runWorkitemCreate(cmd *cobra.Command, args []string) error {
 	assignedTo, _ := cmd.Flags().GetString("assigned-to")
 	areaPath, _ := cmd.Flags().GetString("area-path")
 	iterationPath, _ := cmd.Flags().GetString("iteration-path")
+	tags, _ := cmd.Flags().GetString("tags")
 
 	if wiType == "" {
 		return fmt.Errorf("--type is required")
@@ -241,15 +650,27 @@ func runWorkitemCreate(cmd *cobra.Command, args []string) error {
 	if title == "" {
 		return fmt.Errorf("--title is required")
 	}
+	if wiType, err = checkWorkItemType(ctx, client, project, wiType); err != nil {
+		return err
+	}
+	desc, err = loadDescription(cmd, desc)
+	if err != nil {
+		return err
+	}
 
 	fields := []api.PatchField{
 		{Op: "add", Path: "/fields/System.Title", Value: title},
 	}
 	if desc != "" {
 		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/System.Description", Value: desc})
+		fields = append(fields, markdownFormatField(cmd)...)
 	}
 	if assignedTo != "" {
-		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/System.AssignedTo", Value: assignedTo})
+		assignee, err := resolveAssignee(ctx, client, assignedTo)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/System.AssignedTo", Value: assignee})
 	}
 	if areaPath != "" {
 		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/System.AreaPath", Value: areaPath})
@@ -257,17 +678,39 @@ func runWorkitemCreate(cmd *cobra.Command, args []string) error {
 	if iterationPath != "" {
 		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/System.IterationPath", Value: iterationPath})
 	}
+	if tags != "" {
+		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/System.Tags", Value: joinTags(parseTags(tags))})
+	}
+	// Planning fields are numeric; send them as JSON numbers, not strings.
+	if cmd.Flags().Changed("story-points") {
+		v, _ := cmd.Flags().GetFloat64("story-points")
+		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/Microsoft.VSTS.Scheduling.StoryPoints", Value: v})
+	}
+	if cmd.Flags().Changed("effort") {
+		v, _ := cmd.Flags().GetFloat64("effort")
+		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/Microsoft.VSTS.Scheduling.Effort", Value: v})
+	}
+	if cmd.Flags().Changed("priority") {
+		v, _ := cmd.Flags().GetInt("priority")
+		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/Microsoft.VSTS.Common.Priority", Value: v})
+	}
+	extra, err := parseFieldFlags(cmd, "add")
+	if err != nil {
+		return err
+	}
+	fields = append(fields, extra...)
 
-	wi, err := client.CreateWorkItem(project, wiType, fields)
+	wi, err := client.CreateWorkItem(ctx, project, wiType, fields)
 	if err != nil {
 		return fmt.Errorf("creating work item: %w", err)
 	}
+	wi.WebURL = client.WorkItemWebURL(workItemProject(wi, project), wi.ID)
 
-	switch OutputFormat() {
+	switch commandOutputFormat(cmd) {
+	case "id":
+		fmt.Println(wi.ID)
 	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(wi)
+		return printJSON(wi)
 	case "plain":
 		fmt.Printf("%d\t%s\n", wi.ID, fieldStr(wi.Fields, "System.Title"))
 	default:
@@ -292,6 +735,7 @@ func runWorkitemUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid work item ID: %s", args[0])
 	}
 
+	ctx := cmd.Context()
 	client, err := newAPIClient()
 	if err != nil {
 		return err
@@ -305,6 +749,12 @@ func runWorkitemUpdate(cmd *cobra.Command, args []string) error {
 	title, _ := cmd.Flags().GetString("title")
 	state, _ := cmd.Flags().GetString("state")
 	assignedTo, _ := cmd.Flags().GetString("assigned-to")
+	desc, _ := cmd.Flags().GetString("description")
+	areaPath, _ := cmd.Flags().GetString("area-path")
+	iterationPath, _ := cmd.Flags().GetString("iteration-path")
+	tags, _ := cmd.Flags().GetString("tags")
+	addTags, _ := cmd.Flags().GetStringSlice("add-tag")
+	removeTags, _ := cmd.Flags().GetStringSlice("remove-tag")
 
 	var fields []api.PatchField
 	if title != "" {
@@ -314,23 +764,66 @@ func runWorkitemUpdate(cmd *cobra.Command, args []string) error {
 		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/System.State", Value: state})
 	}
 	if assignedTo != "" {
-		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/System.AssignedTo", Value: assignedTo})
+		assignee, err := resolveAssignee(ctx, client, assignedTo)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/System.AssignedTo", Value: assignee})
+	}
+	if areaPath != "" {
+		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/System.AreaPath", Value: areaPath})
+	}
+	if iterationPath != "" {
+		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/System.IterationPath", Value: iterationPath})
+	}
+
+	if desc != "" {
+		desc, err = loadDescription(cmd, desc)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/System.Description", Value: desc})
+		fields = append(fields, markdownFormatField(cmd)...)
+	}
+	if tags != "" {
+		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/System.Tags", Value: joinTags(parseTags(tags))})
+	}
+	if len(addTags) > 0 || len(removeTags) > 0 {
+		// Merge with the current tags so existing ones are not clobbered.
+		current, err := client.GetWorkItem(ctx, project, id, "")
+		if err != nil {
+			return fmt.Errorf("fetching work item %d: %w", id, err)
+		}
+		merged := mergeTags(parseTags(fieldStr(current.Fields, "System.Tags")), addTags, removeTags)
+		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/System.Tags", Value: joinTags(merged)})
+	}
+	extra, err := parseFieldFlags(cmd, "replace")
+	if err != nil {
+		return err
 	}
+	fields = append(fields, extra...)
 
 	if len(fields) == 0 {
-		return fmt.Errorf("no fields to update (use --title, --state, or --assigned-to)")
+		return fmt.Errorf("no fields to update (use --title, --state, --assigned-to, --area-path, --iteration-path, --field, or a tag flag)")
 	}
 
-	wi, err := client.UpdateWorkItem(project, id, fields)
+	wi, err := client.UpdateWorkItem(ctx, project, id, fields)
 	if err != nil {
+		var apiErr *api.Error
+		if state != "" && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+			if hint := stateHint(ctx, client, project, id, state); hint != "" {
+				return fmt.Errorf("updating work item %d: %w (%s)", id, err, hint)
+			}
+		}
 		return fmt.Errorf("updating work item %d: %w", id, err)
 	}
+	wi.WebURL = client.WorkItemWebURL(workItemProject(wi, project), wi.ID)
 
-	switch OutputFormat() {
+	switch commandOutputFormat(cmd) {
+	case "id":
+		fmt.Println(wi.ID)
 	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(wi)
+		return printJSON(wi)
 	case "plain":
 		fmt.Printf("%d\t%s\n", wi.ID, fieldStr(wi.Fields, "System.Title"))
 	default:
@@ -339,59 +832,1100 @@ func runWorkitemUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// --- helpers ---
+// checkWorkItemType validates a work item type name against the project's
+// types and returns its canonical spelling. Unknown names get a suggestion,
+// e.g. unknown work item type "Buug"; did you mean "Bug"? If the types
+// can't be fetched the name is passed through for the server to judge.
+func checkWorkItemType(ctx context.Context, client *api.Client, project, name string) (string, error) {
+	types, err := client.GetWorkItemTypes(ctx, project)
+	if err != nil {
+		return name, nil
+	}
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		if t.IsDisabled {
+			continue
+		}
+		if strings.EqualFold(t.Name, name) {
+			return t.Name, nil
+		}
+		names = append(names, t.Name)
+	}
+	if best, ok := closestMatch(name, names); ok {
+		return "", fmt.Errorf("unknown work item type %q; did you mean %q?", name, best)
+	}
+	return "", fmt.Errorf("unknown work item type %q (valid: %s)", name, strings.Join(names, ", "))
+}
 
-func fieldStr(fields map[string]interface{}, key string) string {
-	v, ok := fields[key]
-	if !ok {
-		return ""
+// closestMatch returns the candidate with the smallest case-insensitive edit
+// distance to s, if it is close enough to be a plausible typo.
+func closestMatch(s string, candidates []string) (string, bool) {
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := editDistance(strings.ToLower(s), strings.ToLower(c))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
 	}
-	// AssignedTo may come as a nested object with displayName.
-	if m, ok := v.(map[string]interface{}); ok {
-		if name, ok := m["displayName"].(string); ok {
-			return name
+	limit := max(2, utf8.RuneCountInString(s)/3)
+	return best, bestDist >= 0 && bestDist <= limit
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
 		}
+		prev, cur = cur, prev
 	}
-	return fmt.Sprintf("%v", v)
+	return prev[len(rb)]
 }
 
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
+// resolveAssignee maps an --assigned-to value (email, account name, display
+// name, or @me) to the unique name Azure DevOps expects in System.AssignedTo.
+// Azure DevOps silently drops values it doesn't recognise, so an identity
+// that can't be resolved is an error.
+func resolveAssignee(ctx context.Context, client *api.Client, value string) (string, error) {
+	var identity *api.IdentityRef
+	if value == "@me" {
+		conn, err := client.GetConnectionData(ctx)
+		if err != nil {
+			return "", fmt.Errorf("getting authenticated user: %w", err)
+		}
+		identity = &conn.AuthenticatedUser
+	} else {
+		var err error
+		identity, err = client.ResolveIdentity(ctx, value)
+		if err != nil {
+			return "", fmt.Errorf("resolving --assigned-to: %w", err)
+		}
+	}
+	if identity.UniqueName != "" {
+		return identity.UniqueName, nil
 	}
-	return s[:max-3] + "..."
+	return identity.ID, nil
 }
 
-func init() {
-	// List flags
-	wiListCmd.Flags().StringP("project", "p", "", "Project name")
-	wiListCmd.Flags().String("type", "", "Work item type (Bug, Task, User Story, etc.)")
-	wiListCmd.Flags().String("state", "", "Filter by state (New, Active, Closed, etc.)")
-	wiListCmd.Flags().String("assigned-to", "", "Filter by assigned user (@me for current user)")
-	wiListCmd.Flags().Int("top", 20, "Maximum number of results")
+// stateHint looks up the valid states of a work item's type and suggests the
+// one closest to state, e.g. `did you mean "Closed"?`. The value is never
+// corrected automatically. It returns "" if the states can't be fetched.
+func stateHint(ctx context.Context, client *api.Client, project string, id int, state string) string {
+	wi, err := client.GetWorkItem(ctx, project, id, "")
+	if err != nil {
+		return ""
+	}
+	witType := fieldStr(wi.Fields, "System.WorkItemType")
+	states, err := client.GetWorkItemTypeStates(ctx, workItemProject(wi, project), witType)
+	if err != nil || len(states) == 0 {
+		return ""
+	}
 
-	// Show flags
-	wiShowCmd.Flags().StringP("project", "p", "", "Project name")
+	names := make([]string, 0, len(states))
+	for _, s := range states {
+		names = append(names, s.Name)
+	}
+	// Prefer a case-insensitive exact match, then a prefix match, then a
+	// likely typo.
+	for _, n := range names {
+		if strings.EqualFold(n, state) {
+			return fmt.Sprintf("did you mean %q?", n)
+		}
+	}
+	for _, n := range names {
+		if strings.HasPrefix(strings.ToLower(n), strings.ToLower(state)) {
+			return fmt.Sprintf("did you mean %q?", n)
+		}
+	}
+	if n, ok := closestMatch(state, names); ok {
+		return fmt.Sprintf("did you mean %q?", n)
+	}
+	return fmt.Sprintf("valid states for %s: %s", witType, strings.Join(names, ", "))
+}
 
-	// Create flags
-	wiCreateCmd.Flags().StringP("project", "p", "", "Project name")
-	wiCreateCmd.Flags().String("type", "", "Work item type (required)")
-	wiCreateCmd.Flags().String("title", "", "Title (required)")
-	wiCreateCmd.Flags().String("description", "", "Description")
-	wiCreateCmd.Flags().String("assigned-to", "", "Assigned to user")
-	wiCreateCmd.Flags().String("area-path", "", "Area path")
-	wiCreateCmd.Flags().String("iteration-path", "", "Iteration path")
+// --- ado workitem move ---
 
-	// Update flags
-	wiUpdateCmd.Flags().StringP("project", "p", "", "Project name")
-	wiUpdateCmd.Flags().String("title", "", "New title")
-	wiUpdateCmd.Flags().String("state", "", "New state")
-	wiUpdateCmd.Flags().String("assigned-to", "", "New assigned user")
+var wiMoveCmd = &cobra.Command{
+	Use:   "move <id>",
+	Short: "Move a work item to another project",
+	Long: `Move a work item to another project in the same organization. The area and
+iteration paths default to the destination project's root.
+
+  ado workitem move 42 --to-project Mobile --area-path "Mobile\\iOS"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkitemMove,
+}
+
+func runWorkitemMove(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+	toProject, _ := cmd.Flags().GetString("to-project")
+	areaPath, _ := cmd.Flags().GetString("area-path")
+	iterationPath, _ := cmd.Flags().GetString("iteration-path")
+	if toProject == "" {
+		return fmt.Errorf("--to-project is required")
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	// Validate the destination and use its canonical name for the paths.
+	dest, err := client.GetProject(ctx, toProject)
+	if err != nil {
+		return fmt.Errorf("looking up project %q: %w", toProject, err)
+	}
+
+	wi, err := client.MoveWorkItem(ctx, id, dest.Name, areaPath, iterationPath)
+	if err != nil {
+		return fmt.Errorf("moving work item %d: %w", id, err)
+	}
+	wi.WebURL = client.WorkItemWebURL(workItemProject(wi, dest.Name), wi.ID)
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(wi)
+	case "plain":
+		fmt.Printf("%d\t%s\t%s\t%s\n", wi.ID, fieldStr(wi.Fields, "System.TeamProject"),
+			fieldStr(wi.Fields, "System.AreaPath"), fieldStr(wi.Fields, "System.IterationPath"))
+	default:
+		fmt.Printf("Moved work item %d to %s\n", wi.ID, fieldStr(wi.Fields, "System.TeamProject"))
+		fmt.Printf("Area:      %s\n", fieldStr(wi.Fields, "System.AreaPath"))
+		fmt.Printf("Iteration: %s\n", fieldStr(wi.Fields, "System.IterationPath"))
+		fmt.Printf("URL:       %s\n", wi.WebURL)
+	}
+	return nil
+}
+
+// --- ado workitem clone ---
+
+var wiCloneCmd = &cobra.Command{
+	Use:   "clone <id>",
+	Short: "Create a copy of a work item",
+	Long: `Create a new work item from an existing one, copying its type and the fields
+named by --fields (title, description, area, iteration, tags; all by default).
+
+  ado workitem clone 42 --title "Release checklist 2.4" --link
+  ado workitem clone 42 --type Task --fields title,area,iteration`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkitemClone,
+}
+
+// cloneFields maps the --fields names of 'workitem clone' to field reference names.
+var cloneFields = map[string]string{
+	"title":       "System.Title",
+	"description": "System.Description",
+	"area":        "System.AreaPath",
+	"iteration":   "System.IterationPath",
+	"tags":        "System.Tags",
+}
+
+func runWorkitemClone(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+	wiType, _ := cmd.Flags().GetString("type")
+	title, _ := cmd.Flags().GetString("title")
+	names, _ := cmd.Flags().GetStringSlice("fields")
+	link, _ := cmd.Flags().GetBool("link")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	src, err := client.GetWorkItem(ctx, project, id, "")
+	if err != nil {
+		return fmt.Errorf("fetching work item %d: %w", id, err)
+	}
+	if wiType == "" {
+		wiType = fieldStr(src.Fields, "System.WorkItemType")
+	} else if wiType, err = checkWorkItemType(ctx, client, project, wiType); err != nil {
+		return err
+	}
+
+	var fields []api.PatchField
+	for _, name := range names {
+		ref, ok := cloneFields[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return fmt.Errorf("unknown field %q for --fields (valid: title, description, area, iteration, tags)", name)
+		}
+		if ref == "System.Title" && title != "" {
+			continue
+		}
+		if v := fieldStr(src.Fields, ref); v != "" {
+			fields = append(fields, api.PatchField{Op: "add", Path: "/fields/" + ref, Value: v})
+		}
+	}
+	if title != "" {
+		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/System.Title", Value: title})
+	} else if !slices.ContainsFunc(fields, func(f api.PatchField) bool { return f.Path == "/fields/System.Title" }) {
+		return fmt.Errorf("--title is required when the title is not copied")
+	}
+	if link {
+		fields = append(fields, api.PatchField{Op: "add", Path: "/relations/-",
+			Value: api.WorkItemRelation{Rel: api.RelRelated, URL: client.WorkItemAPIURL(src.ID)}})
+	}
+
+	wi, err := client.CreateWorkItem(ctx, project, wiType, fields)
+	if err != nil {
+		return fmt.Errorf("cloning work item %d: %w", id, err)
+	}
+	wi.WebURL = client.WorkItemWebURL(workItemProject(wi, project), wi.ID)
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(wi)
+	case "plain":
+		fmt.Printf("%d\t%s\n", wi.ID, fieldStr(wi.Fields, "System.Title"))
+	default:
+		fmt.Printf("Cloned work item %d as %d: %s\n", id, wi.ID, fieldStr(wi.Fields, "System.Title"))
+	}
+	return nil
+}
+
+// --- ado workitem reopen ---
+
+var wiReopenCmd = &cobra.Command{
+	Use:   "reopen <id>...",
+	Short: "Reopen closed work items",
+	Long: `Move closed or resolved work items back to their type's active state.
+
+The target state is looked up from each work item type's workflow (the first
+InProgress state, e.g. "Active" or "Doing"). The reason is left to the
+workflow default unless --reason is given.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runWorkitemReopen,
+}
+
+func runWorkitemReopen(cmd *cobra.Command, args []string) error {
+	ids := make([]int, 0, len(args))
+	for _, a := range args {
+		id, err := strconv.Atoi(a)
+		if err != nil {
+			return fmt.Errorf("invalid work item ID: %s", a)
+		}
+		ids = append(ids, id)
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	reason, _ := cmd.Flags().GetString("reason")
+
+	// Workflow states per work item type, fetched once per type.
+	statesByType := map[string][]api.WorkItemStateColor{}
+
+	var updated []*api.WorkItem
+	failed := 0
+	for _, id := range ids {
+		wi, err := reopenWorkItem(ctx, client, project, id, reason, statesByType)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: work item %d: %v\n", id, err)
+			failed++
+			continue
+		}
+		updated = append(updated, wi)
+
+		switch OutputFormat() {
+		case "json":
+			// Encoded together after the loop.
+		case "plain":
+			fmt.Printf("%d\t%s\n", wi.ID, fieldStr(wi.Fields, "System.State"))
+		default:
+			fmt.Printf("Reopened work item %d: %s (%s)\n", wi.ID, fieldStr(wi.Fields, "System.Title"), fieldStr(wi.Fields, "System.State"))
+		}
+	}
+
+	if OutputFormat() == "json" {
+		if updated == nil {
+			updated = []*api.WorkItem{}
+		}
+		if err := printJSON(updated); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return afterResult(fmt.Errorf("failed to reopen %d of %d work items", failed, len(ids)))
+	}
+	return nil
+}
+
+// reopenWorkItem transitions a single closed work item to its type's active state.
+func reopenWorkItem(ctx context.Context, client *api.Client, project string, id int, reason string, statesByType map[string][]api.WorkItemStateColor) (*api.WorkItem, error) {
+	wi, err := client.GetWorkItem(ctx, project, id, "")
+	if err != nil {
+		return nil, fmt.Errorf("fetching: %w", err)
+	}
+	wiType := fieldStr(wi.Fields, "System.WorkItemType")
+	current := fieldStr(wi.Fields, "System.State")
+
+	states, err := workItemTypeStates(ctx, client, project, wiType, statesByType)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentCategory, active string
+	for _, st := range states {
+		if strings.EqualFold(st.Name, current) {
+			currentCategory = st.Category
+		}
+		if active == "" && st.Category == "InProgress" {
+			active = st.Name
+		}
+	}
+	if active == "" {
+		return nil, fmt.Errorf("work item type %q has no active (InProgress) state", wiType)
+	}
+	if currentCategory != "Resolved" && currentCategory != "Completed" {
+		return nil, fmt.Errorf("state %q is not closed or resolved; nothing to reopen", current)
+	}
+
+	fields := []api.PatchField{
+		{Op: "replace", Path: "/fields/System.State", Value: active},
+	}
+	if reason != "" {
+		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/System.Reason", Value: reason})
+	}
+	return client.UpdateWorkItem(ctx, project, id, fields)
+}
+
+// workItemTypeStates returns the workflow states of a work item type,
+// fetching them once per type and caching them in cache.
+func workItemTypeStates(ctx context.Context, client *api.Client, project, wiType string, cache map[string][]api.WorkItemStateColor) ([]api.WorkItemStateColor, error) {
+	if states, ok := cache[wiType]; ok {
+		return states, nil
+	}
+	states, err := client.GetWorkItemTypeStates(ctx, project, wiType)
+	if err != nil {
+		return nil, fmt.Errorf("fetching states for %q: %w", wiType, err)
+	}
+	cache[wiType] = states
+	return states, nil
+}
+
+// validateState checks that target is a state of the work item type. A match
+// that differs only in case is reported with the correct spelling rather than
+// silently fixed.
+func validateState(states []api.WorkItemStateColor, wiType, target string) error {
+	names := make([]string, 0, len(states))
+	for _, st := range states {
+		if st.Name == target {
+			return nil
+		}
+		if strings.EqualFold(st.Name, target) {
+			return fmt.Errorf("invalid state %q for %s; did you mean %q?", target, wiType, st.Name)
+		}
+		names = append(names, st.Name)
+	}
+	return fmt.Errorf("invalid state %q for %s (valid: %s)", target, wiType, strings.Join(names, ", "))
+}
+
+// --- ado workitem history ---
+
+var wiHistoryCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show work item revision history",
+	Long:  "List the revisions of a work item, newest first.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWorkitemHistory,
+}
+
+func runWorkitemHistory(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	top, _ := cmd.Flags().GetInt("top")
+
+	revs, err := client.GetWorkItemRevisions(ctx, project, id)
+	if err != nil {
+		return fmt.Errorf("fetching revisions of work item %d: %w", id, err)
+	}
+
+	// The API returns oldest first; show the most recent changes first.
+	for i, j := 0, len(revs)-1; i < j; i, j = i+1, j-1 {
+		revs[i], revs[j] = revs[j], revs[i]
+	}
+	if top > 0 && len(revs) > top {
+		revs = revs[:top]
+	}
+
+	defer startPager()()
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(revs)
+	case "plain":
+		for _, r := range revs {
+			fmt.Printf("%d\t%s\t%s\n", r.Rev, fieldStr(r.Fields, "System.ChangedDate"), fieldStr(r.Fields, "System.State"))
+		}
+	default: // table
+		fmt.Fprintf(os.Stdout, "%-5s %-22s %-25s %-12s\n", "Rev", "Changed", "Changed By", "State")
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", 67))
+		for _, r := range revs {
+			fmt.Fprintf(os.Stdout, "%-5d %-22s %-25s %-12s\n",
+				r.Rev,
+				truncate(fieldStr(r.Fields, "System.ChangedDate"), 22),
+				truncate(fieldStr(r.Fields, "System.ChangedBy"), 25),
+				fieldStr(r.Fields, "System.State"),
+			)
+		}
+	}
+	return nil
+}
+
+// --- ado workitem link ---
+
+var wiLinkCmd = &cobra.Command{
+	Use:   "link <id>",
+	Short: "Link work items",
+	Long: `Add or remove links between work items.
+
+  ado workitem link 123 --parent 100
+  ado workitem link 100 --child 123 --child 124
+  ado workitem link 123 --related 130
+  ado workitem link 123 --remove-parent`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkitemLink,
+}
+
+func runWorkitemLink(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	parent, _ := cmd.Flags().GetInt("parent")
+	children, _ := cmd.Flags().GetIntSlice("child")
+	related, _ := cmd.Flags().GetIntSlice("related")
+	removeParent, _ := cmd.Flags().GetBool("remove-parent")
+
+	type link struct {
+		rel    string
+		target int
+	}
+	var links []link
+	if parent > 0 {
+		links = append(links, link{api.RelHierarchyReverse, parent})
+	}
+	for _, c := range children {
+		links = append(links, link{api.RelHierarchyForward, c})
+	}
+	for _, r := range related {
+		links = append(links, link{api.RelRelated, r})
+	}
+	if len(links) == 0 && !removeParent {
+		return fmt.Errorf("nothing to do (use --parent, --child, --related, or --remove-parent)")
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	var wi *api.WorkItem
+	if removeParent {
+		current, err := client.GetWorkItem(ctx, project, id, "relations")
+		if err != nil {
+			return fmt.Errorf("fetching work item %d: %w", id, err)
+		}
+		index := -1
+		for i, rel := range current.Relations {
+			if rel.Rel == api.RelHierarchyReverse {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("work item %d has no parent", id)
+		}
+		if wi, err = client.RemoveWorkItemRelation(ctx, project, id, index); err != nil {
+			return fmt.Errorf("removing parent of work item %d: %w", id, err)
+		}
+	}
+
+	for _, l := range links {
+		if wi, err = client.AddWorkItemRelation(ctx, project, id, l.rel, client.WorkItemAPIURL(l.target)); err != nil {
+			return fmt.Errorf("linking work item %d to %d: %w", id, l.target, err)
+		}
+	}
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(wi)
+	case "plain":
+		fmt.Printf("%d\t%s\n", wi.ID, fieldStr(wi.Fields, "System.Title"))
+	default:
+		fmt.Printf("Updated links on work item %d: %s\n", wi.ID, fieldStr(wi.Fields, "System.Title"))
+	}
+	return nil
+}
+
+// --- ado workitem attach ---
+
+var wiAttachCmd = &cobra.Command{
+	Use:   "attach <id>",
+	Short: "Attach a file to a work item",
+	Long: `Upload a file and attach it to a work item.
+
+  ado workitem attach 42 --file build.log --comment "Nightly run"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkitemAttach,
+}
+
+func runWorkitemAttach(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+	path, _ := cmd.Flags().GetString("file")
+	name, _ := cmd.Flags().GetString("name")
+	comment, _ := cmd.Flags().GetString("comment")
+	if path == "" {
+		return fmt.Errorf("--file is required")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening attachment: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("opening attachment: %w", err)
+	}
+	if name == "" {
+		name = filepath.Base(path)
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	ref, err := client.UploadAttachment(ctx, project, name, f)
+	if err != nil {
+		return fmt.Errorf("uploading %s: %w", name, err)
+	}
+	if _, err := client.AddAttachment(ctx, project, id, ref.URL, comment); err != nil {
+		return fmt.Errorf("attaching %s to work item %d: %w", name, id, err)
+	}
+
+	switch OutputFormat() {
+	case "json":
+		out := map[string]interface{}{
+			"workItemId": id,
+			"name":       name,
+			"size":       info.Size(),
+			"url":        ref.URL,
+		}
+		return printJSON(out)
+	case "plain":
+		fmt.Printf("%d\t%s\t%d\t%s\n", id, name, info.Size(), ref.URL)
+	default:
+		fmt.Printf("Attached %s (%s) to work item %d\n", name, formatSize(info.Size()), id)
+	}
+	return nil
+}
+
+// --- ado workitem tree ---
+
+var wiTreeCmd = &cobra.Command{
+	Use:   "tree <id>",
+	Short: "Show a work item and its descendants",
+	Long: `Show a work item and its children as an indented tree.
+
+Children are followed through parent/child links, one batched request per
+level, up to --depth levels below the root.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkitemTree,
+}
+
+// workItemNode is a work item in a parent/child tree.
+type workItemNode struct {
+	ID       int             `json:"id"`
+	Type     string          `json:"type"`
+	Title    string          `json:"title"`
+	State    string          `json:"state"`
+	Children []*workItemNode `json:"children,omitempty"`
+}
+
+func runWorkitemTree(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	depth, _ := cmd.Flags().GetInt("depth")
+	if depth < 0 {
+		return fmt.Errorf("--depth must not be negative")
+	}
+
+	root, err := buildWorkItemTree(ctx, client, project, id, depth)
+	if err != nil {
+		return err
+	}
+
+	defer startPager()()
+
+	switch OutputFormat() {
+	case "json":
+		return printJSON(root)
+	case "plain":
+		printWorkItemTree(root, 0, func(n *workItemNode, indent string) {
+			fmt.Printf("%s%d\t%s\n", indent, n.ID, n.Title)
+		})
+	default: // table
+		printWorkItemTree(root, 0, func(n *workItemNode, indent string) {
+			fmt.Printf("%s%d [%s] %s (%s)\n", indent, n.ID, n.State, n.Title, n.Type)
+		})
+	}
+	return nil
+}
+
+// buildWorkItemTree fetches a work item and its descendants breadth-first,
+// one batch call per level. Items already seen are skipped, so link cycles
+// terminate.
+func buildWorkItemTree(ctx context.Context, client *api.Client, project string, rootID, depth int) (*workItemNode, error) {
+	nodes := map[int]*workItemNode{}
+	visited := map[int]bool{rootID: true}
+	var root *workItemNode
+
+	level := []int{rootID}
+	parents := map[int]int{} // child ID -> parent ID
+	for d := 0; len(level) > 0; d++ {
+		items, err := client.GetWorkItemsWithOptions(ctx, project, level, api.WorkItemsOptions{Expand: "relations"})
+		if err != nil {
+			return nil, fmt.Errorf("fetching work items: %w", err)
+		}
+
+		var next []int
+		for _, wi := range items {
+			n := &workItemNode{
+				ID:    wi.ID,
+				Type:  fieldStr(wi.Fields, "System.WorkItemType"),
+				Title: fieldStr(wi.Fields, "System.Title"),
+				State: fieldStr(wi.Fields, "System.State"),
+			}
+			nodes[wi.ID] = n
+			if wi.ID == rootID {
+				root = n
+			} else if p, ok := nodes[parents[wi.ID]]; ok {
+				p.Children = append(p.Children, n)
+			}
+
+			if d >= depth {
+				continue
+			}
+			for _, rel := range wi.Relations {
+				if rel.Rel != api.RelHierarchyForward {
+					continue
+				}
+				childID, ok := rel.TargetID()
+				if !ok || visited[childID] {
+					continue
+				}
+				visited[childID] = true
+				parents[childID] = wi.ID
+				next = append(next, childID)
+			}
+		}
+		level = next
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("work item %d not found", rootID)
+	}
+	return root, nil
+}
+
+// printWorkItemTree walks the tree depth-first, calling line with a
+// two-space indent per level.
+func printWorkItemTree(n *workItemNode, level int, line func(n *workItemNode, indent string)) {
+	line(n, strings.Repeat("  ", level))
+	for _, c := range n.Children {
+		printWorkItemTree(c, level+1, line)
+	}
+}
+
+// --- helpers ---
+
+func fieldStr(fields map[string]interface{}, key string) string {
+	v, ok := fields[key]
+	if !ok {
+		return ""
+	}
+	// AssignedTo may come as a nested object with displayName.
+	if m, ok := v.(map[string]interface{}); ok {
+		if name, ok := m["displayName"].(string); ok {
+			return name
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// workItemProject returns the project a work item belongs to, falling back
+// to the given default when the field is missing.
+func workItemProject(wi *api.WorkItem, fallback string) string {
+	if p := fieldStr(wi.Fields, "System.TeamProject"); p != "" {
+		return p
+	}
+	return fallback
+}
+
+// relationString formats a relation as "<type>: <target>", using the
+// friendly link name when the API provides one.
+func relationString(rel api.WorkItemRelation) string {
+	name, _ := rel.Attributes["name"].(string)
+	if name == "" {
+		name = rel.Rel
+	}
+	if id, ok := rel.TargetID(); ok {
+		return fmt.Sprintf("%s: %d", name, id)
+	}
+	return fmt.Sprintf("%s: %s", name, rel.URL)
+}
+
+// parseFieldFlags turns repeated --field name=value flags into patch
+// operations. Names without a dot are taken to be System fields, and values
+// that parse as numbers are sent as JSON numbers.
+func parseFieldFlags(cmd *cobra.Command, op string) ([]api.PatchField, error) {
+	values, _ := cmd.Flags().GetStringArray("field")
+	fields := make([]api.PatchField, 0, len(values))
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --field %q (expected name=value)", v)
+		}
+		if !strings.Contains(name, ".") {
+			name = "System." + name
+		}
+		fields = append(fields, api.PatchField{Op: op, Path: "/fields/" + name, Value: fieldValue(value)})
+	}
+	return fields, nil
+}
+
+// fieldValue converts a flag value to a JSON number when it looks like one,
+// since Azure DevOps rejects numeric fields sent as strings.
+func fieldValue(s string) interface{} {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f
+	}
+	return s
+}
+
+// parseDate turns a date flag value into YYYY-MM-DD for WIQL. It accepts an
+// absolute date or a relative one such as -7d or -2w, counted back from now.
+func parseDate(value string, now time.Time) (string, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.Format("2006-01-02"), nil
+	}
+	if len(value) >= 3 && value[0] == '-' {
+		n, err := strconv.Atoi(value[1 : len(value)-1])
+		if err == nil && n >= 0 {
+			switch value[len(value)-1] {
+			case 'd':
+				return now.AddDate(0, 0, -n).Format("2006-01-02"), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*n).Format("2006-01-02"), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%q is not a date (use YYYY-MM-DD, -7d, or -2w)", value)
+}
+
+// loadDescription resolves a --description value: "@path" reads a file, "-"
+// reads stdin, and anything else is used as-is. The content is sent unchanged;
+// markdown is marked as such by markdownFormatField rather than converted.
+func loadDescription(cmd *cobra.Command, value string) (string, error) {
+	if _, err := descriptionIsMarkdown(cmd); err != nil {
+		return "", err
+	}
+
+	content := value
+	switch {
+	case value == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("reading description from stdin: %w", err)
+		}
+		content = string(data)
+	case strings.HasPrefix(value, "@"):
+		data, err := os.ReadFile(value[1:])
+		if err != nil {
+			return "", fmt.Errorf("reading description file: %w", err)
+		}
+		content = string(data)
+	}
+
+	return content, nil
+}
+
+// descriptionIsMarkdown reports whether the description is markdown, either
+// through --description-format markdown or its shorthand
+// --description-is-markdown.
+func descriptionIsMarkdown(cmd *cobra.Command) (bool, error) {
+	if isMarkdown, _ := cmd.Flags().GetBool("description-is-markdown"); isMarkdown {
+		return true, nil
+	}
+	format, _ := cmd.Flags().GetString("description-format")
+	switch format {
+	case "", "html":
+		return false, nil
+	case "markdown":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid --description-format %q (must be html or markdown)", format)
+	}
+}
+
+// markdownFormatField returns the patch operation that marks the description
+// as markdown when it is one. Process templates that support it then render
+// the markdown instead of showing it as HTML text.
+func markdownFormatField(cmd *cobra.Command) []api.PatchField {
+	if isMarkdown, _ := descriptionIsMarkdown(cmd); !isMarkdown {
+		return nil
+	}
+	return []api.PatchField{{Op: "add", Path: "/multilineFieldsFormat/System.Description", Value: "Markdown"}}
+}
+
+// parseTags splits a tag list on commas or semicolons (the ADO separator),
+// trimming whitespace and dropping empty entries.
+func parseTags(s string) []string {
+	var tags []string
+	for _, t := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// joinTags formats tags as the System.Tags field value.
+func joinTags(tags []string) string {
+	return strings.Join(tags, "; ")
+}
+
+// mergeTags adds and removes tags from current. Tags compare
+// case-insensitively, as they do in Azure DevOps.
+func mergeTags(current, add, remove []string) []string {
+	removed := map[string]bool{}
+	for _, t := range remove {
+		removed[strings.ToLower(strings.TrimSpace(t))] = true
+	}
+	seen := map[string]bool{}
+	var merged []string
+	for _, t := range append(current, add...) {
+		t = strings.TrimSpace(t)
+		key := strings.ToLower(t)
+		if t == "" || removed[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, t)
+	}
+	return merged
+}
+
+// truncate shortens s to at most max characters, ending in "..." when cut.
+// It counts runes rather than bytes so multibyte characters are never split.
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	r := []rune(s)
+	if max <= 3 {
+		return string(r[:max])
+	}
+	return string(r[:max-3]) + "..."
+}
+
+func init() {
+	// List flags
+	addProjectFlags(wiListCmd)
+	supportsTemplate(wiListCmd)
+	wiListCmd.Flags().String("type", "", "Work item type (Bug, Task, User Story, etc.)")
+	wiListCmd.Flags().String("state", "", "Filter by state (New, Active, Closed, etc.)")
+	wiListCmd.Flags().String("assigned-to", "", "Filter by assigned user (@me for current user, 'unassigned' for no owner)")
+	wiListCmd.Flags().Bool("assignee-unassigned", false, "Only show work items with no assigned user")
+	wiListCmd.Flags().String("area-path", "", "Filter by area path, including child areas")
+	wiListCmd.Flags().String("iteration-path", "", "Filter by iteration path, including children (@currentIteration for the current sprint)")
+	wiListCmd.Flags().String("created-by", "", "Filter by creator (@me for current user)")
+	wiListCmd.Flags().String("created-after", "", "Only work items created on or after a date: YYYY-MM-DD, or relative like -7d or -2w")
+	wiListCmd.Flags().String("changed-after", "", "Only work items changed on or after a date: YYYY-MM-DD, or relative like -7d or -2w")
+	wiListCmd.Flags().Int("top", 20, "Maximum number of results")
+	wiListCmd.Flags().String("sort", "", "Field to sort by, e.g. System.CreatedDate (default: System.ChangedDate)")
+	wiListCmd.Flags().String("order", "desc", "Sort order (asc, desc)")
+	wiListCmd.Flags().String("fields", "", "Comma-separated fields to fetch and show (default: Type, Title, State, AssignedTo)")
+	wiListCmd.Flags().Bool("show-points", false, "Add a story points column")
+	wiListCmd.Flags().Bool("show-priority", false, "Add a priority column")
+	wiListCmd.Flags().Bool("count", false, "Wrap JSON results in an object with totalCount (matches before --top) and value; requires -o json")
+	wiListCmd.Flags().Bool("exit-code", false, "Exit with status 1 if no work items match")
+
+	// Mine flags
+	addProjectFlags(wiMineCmd)
+	supportsTemplate(wiMineCmd)
+	wiMineCmd.Flags().String("type", "", "Work item type (Bug, Task, User Story, etc.)")
+	wiMineCmd.Flags().String("state", "", "Filter by state (overrides the default of hiding closed items)")
+	wiMineCmd.Flags().Bool("all-states", false, "Include closed and removed work items")
+	wiMineCmd.Flags().Int("top", 20, "Maximum number of results")
+
+	// Show flags
+	addProjectFlags(wiShowCmd)
+	supportsTemplate(wiShowCmd)
+
+	// Create flags
+	addProjectFlags(wiCreateCmd)
+	wiCreateCmd.Flags().String("type", "", "Work item type (required)")
+	wiCreateCmd.Flags().String("title", "", "Title (required)")
+	wiCreateCmd.Flags().String("description", "", "Description (@file to read a file, - for stdin)")
+	wiCreateCmd.Flags().String("description-format", "html", "Description format (html, markdown)")
+	wiCreateCmd.Flags().Bool("description-is-markdown", false, "Store the description as markdown (same as --description-format markdown)")
+	wiCreateCmd.Flags().String("assigned-to", "", "Assigned user: email, account or display name, or @me")
+	wiCreateCmd.Flags().String("area-path", "", "Area path")
+	wiCreateCmd.Flags().String("iteration-path", "", "Iteration path")
+	wiCreateCmd.Flags().Float64("story-points", 0, "Story points (Agile process)")
+	wiCreateCmd.Flags().Float64("effort", 0, "Effort (Scrum process)")
+	wiCreateCmd.Flags().Int("priority", 0, "Priority, 1 (highest) to 4")
+	wiCreateCmd.Flags().String("tags", "", "Comma-separated tags")
+	wiCreateCmd.Flags().StringArray("field", nil, "Set any field as name=value (repeatable)")
+	addIDOnlyFlag(wiCreateCmd)
+	wiCreateCmd.MarkFlagsMutuallyExclusive("description-format", "description-is-markdown")
+
+	// Update flags
+	addProjectFlags(wiUpdateCmd)
+	wiUpdateCmd.Flags().String("title", "", "New title")
+	wiUpdateCmd.Flags().String("state", "", "New state")
+	wiUpdateCmd.Flags().String("assigned-to", "", "New assigned user: email, account or display name, or @me")
+	wiUpdateCmd.Flags().String("description", "", "New description (@file to read a file, - for stdin)")
+	wiUpdateCmd.Flags().String("description-format", "html", "Description format (html, markdown)")
+	wiUpdateCmd.Flags().Bool("description-is-markdown", false, "Store the description as markdown (same as --description-format markdown)")
+	wiUpdateCmd.Flags().String("area-path", "", "New area path")
+	wiUpdateCmd.Flags().String("iteration-path", "", "New iteration path")
+	wiUpdateCmd.Flags().String("tags", "", "Comma-separated tags (replaces existing tags)")
+	wiUpdateCmd.Flags().StringSlice("add-tag", nil, "Tag to add, keeping existing tags (repeatable)")
+	wiUpdateCmd.Flags().StringSlice("remove-tag", nil, "Tag to remove (repeatable)")
+	wiUpdateCmd.Flags().StringArray("field", nil, "Set any field as name=value (repeatable)")
+	addIDOnlyFlag(wiUpdateCmd)
+	wiUpdateCmd.MarkFlagsMutuallyExclusive("description-format", "description-is-markdown")
+	wiUpdateCmd.MarkFlagsMutuallyExclusive("tags", "add-tag")
+	wiUpdateCmd.MarkFlagsMutuallyExclusive("tags", "remove-tag")
+
+	// Move flags
+	wiMoveCmd.Flags().String("to-project", "", "Destination project (required)")
+	_ = wiMoveCmd.RegisterFlagCompletionFunc("to-project", completeProjects)
+	wiMoveCmd.Flags().String("area-path", "", "Area path in the destination (default: project root)")
+	wiMoveCmd.Flags().String("iteration-path", "", "Iteration path in the destination (default: project root)")
+
+	// Clone flags
+	addProjectFlags(wiCloneCmd)
+	wiCloneCmd.Flags().String("type", "", "Work item type of the clone (default: same as the source)")
+	wiCloneCmd.Flags().String("title", "", "Title of the clone (default: the source title)")
+	wiCloneCmd.Flags().StringSlice("fields", []string{"title", "description", "area", "iteration", "tags"}, "Fields to copy: title, description, area, iteration, tags")
+	wiCloneCmd.Flags().Bool("link", false, "Link the clone to the source as Related")
+
+	// Reopen flags
+	addProjectFlags(wiReopenCmd)
+	wiReopenCmd.Flags().String("reason", "", "Reason for the transition (default: workflow default)")
+
+	// History flags
+	addProjectFlags(wiHistoryCmd)
+	wiHistoryCmd.Flags().Int("top", 20, "Maximum number of revisions (newest first)")
+
+	// Link flags
+	addProjectFlags(wiLinkCmd)
+	wiLinkCmd.Flags().Int("parent", 0, "Parent work item ID")
+	wiLinkCmd.Flags().IntSlice("child", nil, "Child work item ID (repeatable)")
+	wiLinkCmd.Flags().IntSlice("related", nil, "Related work item ID (repeatable)")
+	wiLinkCmd.Flags().Bool("remove-parent", false, "Remove the existing parent link")
+
+	// Attach flags
+	addProjectFlags(wiAttachCmd)
+	wiAttachCmd.Flags().String("file", "", "File to attach (required)")
+	wiAttachCmd.Flags().String("name", "", "Attachment name (default: the file's base name)")
+	wiAttachCmd.Flags().String("comment", "", "Comment shown with the attachment")
+
+	// Tree flags
+	addProjectFlags(wiTreeCmd)
+	wiTreeCmd.Flags().Int("depth", 3, "Maximum number of levels below the root")
 
 	workitemCmd.AddCommand(wiListCmd)
+	workitemCmd.AddCommand(wiMineCmd)
 	workitemCmd.AddCommand(wiShowCmd)
 	workitemCmd.AddCommand(wiCreateCmd)
 	workitemCmd.AddCommand(wiUpdateCmd)
+	workitemCmd.AddCommand(wiMoveCmd)
+	workitemCmd.AddCommand(wiCloneCmd)
+	workitemCmd.AddCommand(wiReopenCmd)
+	workitemCmd.AddCommand(wiHistoryCmd)
+	workitemCmd.AddCommand(wiLinkCmd)
+	workitemCmd.AddCommand(wiAttachCmd)
+	workitemCmd.AddCommand(wiTreeCmd)
 
 	rootCmd.AddCommand(workitemCmd)
 }
@@ -0,0 +1,504 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gyurisc/adocli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// --- ado workitem bulk-transition ---
+
+var wiBulkTransitionCmd = &cobra.Command{
+	Use:   "bulk-transition",
+	Short: "Move every result of a saved query to a new state",
+	Long: `Run a saved query and transition every returned work item to the target state.
+
+The target state is validated against each work item type's workflow before
+any update is sent. Items already in the target state are skipped. Progress is
+written to stderr; a per-item report goes to stdout and, with --report, to a
+CSV file.
+
+  ado workitem bulk-transition --saved-query <savedQueryIdOrPath> --to Resolved --dry-run`,
+	RunE: runWorkitemBulkTransition,
+}
+
+// transitionResult is one row of the bulk-transition report.
+type transitionResult struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Status string `json:"status"` // transitioned, skipped, dry-run, failed
+	Error  string `json:"error,omitempty"`
+}
+
+func runWorkitemBulkTransition(cmd *cobra.Command, args []string) error {
+	queryID, _ := cmd.Flags().GetString("saved-query")
+	target, _ := cmd.Flags().GetString("to")
+	reason, _ := cmd.Flags().GetString("reason")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	top, _ := cmd.Flags().GetInt("top")
+	reportPath, _ := cmd.Flags().GetString("report")
+
+	if queryID == "" {
+		return fmt.Errorf("--saved-query is required")
+	}
+	if target == "" {
+		return fmt.Errorf("--to is required")
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	ids, err := runSavedQuery(ctx, client, project, queryID, top)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		fmt.Fprintln(os.Stderr, "No work items found.")
+		return nil
+	}
+
+	items, err := client.GetWorkItems(ctx, project, ids)
+	if err != nil {
+		return fmt.Errorf("fetching work items: %w", err)
+	}
+
+	statesByType := map[string][]api.WorkItemStateColor{}
+	results := make([]transitionResult, 0, len(items))
+	failed := 0
+	for i, wi := range items {
+		r := transitionResult{
+			ID:    wi.ID,
+			Title: fieldStr(wi.Fields, "System.Title"),
+			From:  fieldStr(wi.Fields, "System.State"),
+			To:    target,
+		}
+		r.Status, err = transitionWorkItem(ctx, client, project, &wi, target, reason, dryRun, statesByType)
+		if err != nil {
+			r.Error = err.Error()
+			failed++
+		}
+		results = append(results, r)
+
+		fmt.Fprintf(os.Stderr, "[%d/%d] %d: %s -> %s ... %s\n", i+1, len(items), r.ID, r.From, r.To, r.Status)
+	}
+
+	if reportPath != "" {
+		if err := writeTransitionReport(reportPath, results); err != nil {
+			return err
+		}
+	}
+
+	switch OutputFormat() {
+	case "json":
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	case "plain":
+		for _, r := range results {
+			fmt.Printf("%d\t%s\n", r.ID, r.Status)
+		}
+	default: // table
+		fmt.Fprintf(os.Stdout, "%-8s %-40s %-12s %-12s %-14s %s\n", "ID", "Title", "From", "To", "Result", "Error")
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", 110))
+		for _, r := range results {
+			fmt.Fprintf(os.Stdout, "%-8d %-40s %-12s %-12s %-14s %s\n",
+				r.ID, truncate(r.Title, 40), r.From, r.To, r.Status, r.Error)
+		}
+	}
+
+	if failed > 0 {
+		return afterResult(fmt.Errorf("failed to transition %d of %d work items", failed, len(results)))
+	}
+	return nil
+}
+
+// transitionWorkItem validates and applies a state change to a single work
+// item, returning the result status for the report.
+func transitionWorkItem(ctx context.Context, client *api.Client, project string, wi *api.WorkItem, target, reason string, dryRun bool, statesByType map[string][]api.WorkItemStateColor) (string, error) {
+	wiType := fieldStr(wi.Fields, "System.WorkItemType")
+	states, err := workItemTypeStates(ctx, client, project, wiType, statesByType)
+	if err != nil {
+		return "failed", err
+	}
+	if err := validateState(states, wiType, target); err != nil {
+		return "failed", err
+	}
+	if fieldStr(wi.Fields, "System.State") == target {
+		return "skipped", nil
+	}
+	if dryRun {
+		return "dry-run", nil
+	}
+
+	fields := []api.PatchField{
+		{Op: "replace", Path: "/fields/System.State", Value: target},
+	}
+	if reason != "" {
+		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/System.Reason", Value: reason})
+	}
+	if _, err := client.UpdateWorkItem(ctx, project, wi.ID, fields); err != nil {
+		return "failed", err
+	}
+	return "transitioned", nil
+}
+
+// writeTransitionReport writes the bulk-transition results as CSV.
+func writeTransitionReport(path string, results []transitionResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating report: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{"id", "title", "from", "to", "status", "error"})
+	for _, r := range results {
+		_ = w.Write([]string{strconv.Itoa(r.ID), r.Title, r.From, r.To, r.Status, r.Error})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	return nil
+}
+
+// --- ado workitem bulk-update ---
+
+var wiBulkUpdateCmd = &cobra.Command{
+	Use:   "bulk-update",
+	Short: "Update many work items from a file",
+	Long: `Update work items listed in a JSON file. Each entry names a work item and
+the fields to replace:
+
+  [
+    {"id": 123, "fields": {"System.State": "Closed"}},
+    {"id": 124, "fields": {"System.AssignedTo": "jane@contoso.com"}}
+  ]
+
+A summary of each update is printed, and the command exits non-zero if any
+update failed.`,
+	RunE: runWorkitemBulkUpdate,
+}
+
+// bulkUpdateEntry is one entry of a bulk-update file.
+type bulkUpdateEntry struct {
+	ID     int                    `json:"id"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// bulkResult is one row of a bulk operation summary.
+type bulkResult struct {
+	ID     int    `json:"id"`
+	Result string `json:"result"` // updated, created, failed, skipped
+	Error  string `json:"error,omitempty"`
+}
+
+func runWorkitemBulkUpdate(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("file")
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+
+	if path == "" {
+		return fmt.Errorf("--file is required")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading updates file: %w", err)
+	}
+	var entries []bulkUpdateEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("parsing updates file: %w", err)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no updates in %s", path)
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	results := make([]bulkResult, 0, len(entries))
+	failed := 0
+	for i, e := range entries {
+		if failed > 0 && !continueOnError {
+			results = append(results, bulkResult{ID: e.ID, Result: "skipped"})
+			continue
+		}
+
+		r := bulkResult{ID: e.ID, Result: "updated"}
+		if err := bulkUpdateWorkItem(ctx, client, project, e); err != nil {
+			r.Result = "failed"
+			r.Error = err.Error()
+			failed++
+		}
+		results = append(results, r)
+		fmt.Fprintf(os.Stderr, "[%d/%d] %d ... %s\n", i+1, len(entries), r.ID, r.Result)
+	}
+
+	if err := printBulkResults(results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return afterResult(fmt.Errorf("failed to update %d of %d work items", failed, len(entries)))
+	}
+	return nil
+}
+
+// bulkUpdateWorkItem applies one bulk-update entry as replace operations.
+func bulkUpdateWorkItem(ctx context.Context, client *api.Client, project string, e bulkUpdateEntry) error {
+	if e.ID <= 0 {
+		return fmt.Errorf("missing or invalid id")
+	}
+	if len(e.Fields) == 0 {
+		return fmt.Errorf("no fields to update")
+	}
+
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names) // deterministic patch order
+
+	fields := make([]api.PatchField, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, api.PatchField{Op: "replace", Path: "/fields/" + name, Value: e.Fields[name]})
+	}
+	_, err := client.UpdateWorkItem(ctx, project, e.ID, fields)
+	return err
+}
+
+// --- ado workitem bulk-create ---
+
+var wiBulkCreateCmd = &cobra.Command{
+	Use:   "bulk-create",
+	Short: "Create many work items from a file",
+	Long: `Create work items listed in a JSON file. Each entry gives the type, title,
+any other fields, and optionally a parent to link to: either the ID of an
+existing work item ("parent"), or the "key" of an earlier entry in the same
+file ("parentKey"), so a whole hierarchy can be created at once:
+
+  [
+    {"key": "epic", "type": "Epic", "title": "Checkout v2"},
+    {"key": "story", "type": "User Story", "title": "Pay by card", "parentKey": "epic",
+     "fields": {"System.Tags": "checkout"}},
+    {"type": "Task", "title": "Wire up payment API", "parentKey": "story",
+     "fields": {"Microsoft.VSTS.Scheduling.RemainingWork": 4}},
+    {"type": "Task", "title": "Update docs", "parent": 123}
+  ]
+
+A summary of the created IDs is printed, and the command exits non-zero if any
+entry failed.`,
+	RunE: runWorkitemBulkCreate,
+}
+
+// bulkCreateEntry is one entry of a bulk-create file. Key names the entry
+// so later entries can use it as their ParentKey.
+type bulkCreateEntry struct {
+	Key       string                 `json:"key,omitempty"`
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Fields    map[string]interface{} `json:"fields"`
+	Parent    int                    `json:"parent,omitempty"`
+	ParentKey string                 `json:"parentKey,omitempty"`
+}
+
+// checkBulkCreateKeys verifies that keys are unique and that every parentKey
+// names an earlier entry, so problems are reported before anything is created.
+func checkBulkCreateKeys(entries []bulkCreateEntry) error {
+	seen := map[string]bool{}
+	for i, e := range entries {
+		if e.ParentKey != "" {
+			if e.Parent != 0 {
+				return fmt.Errorf("entry %d: use either parent or parentKey, not both", i+1)
+			}
+			if !seen[e.ParentKey] {
+				return fmt.Errorf("entry %d: parentKey %q does not name an earlier entry", i+1, e.ParentKey)
+			}
+		}
+		if e.Key != "" {
+			if seen[e.Key] {
+				return fmt.Errorf("entry %d: duplicate key %q", i+1, e.Key)
+			}
+			seen[e.Key] = true
+		}
+	}
+	return nil
+}
+
+func runWorkitemBulkCreate(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("file")
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+
+	if path == "" {
+		return fmt.Errorf("--file is required")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading work items file: %w", err)
+	}
+	var entries []bulkCreateEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("parsing work items file: %w", err)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no work items in %s", path)
+	}
+	if err := checkBulkCreateKeys(entries); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	results := make([]bulkResult, 0, len(entries))
+	created := map[string]int{} // entry key -> new work item ID
+	failed := 0
+	for i, e := range entries {
+		if failed > 0 && !continueOnError {
+			results = append(results, bulkResult{Result: "skipped", Error: fmt.Sprintf("entry %d", i+1)})
+			continue
+		}
+
+		var id int
+		var err error
+		if e.ParentKey != "" && created[e.ParentKey] == 0 {
+			err = fmt.Errorf("parent entry %q was not created", e.ParentKey)
+		} else {
+			if e.ParentKey != "" {
+				e.Parent = created[e.ParentKey]
+			}
+			id, err = bulkCreateWorkItem(ctx, client, project, e)
+		}
+		if e.Key != "" && id != 0 {
+			created[e.Key] = id
+		}
+		r := bulkResult{ID: id, Result: "created"}
+		if err != nil {
+			if id == 0 {
+				r.Result = "failed"
+			}
+			r.Error = fmt.Sprintf("entry %d: %v", i+1, err)
+			failed++
+		}
+		results = append(results, r)
+		fmt.Fprintf(os.Stderr, "[%d/%d] %s ... %s\n", i+1, len(entries), truncate(e.Title, 40), r.Result)
+	}
+
+	if err := printBulkResults(results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return afterResult(fmt.Errorf("%d of %d work items failed", failed, len(entries)))
+	}
+	return nil
+}
+
+// bulkCreateWorkItem creates one bulk-create entry and links it to its
+// parent. It returns the new ID even if only the parent link failed.
+func bulkCreateWorkItem(ctx context.Context, client *api.Client, project string, e bulkCreateEntry) (int, error) {
+	if e.Type == "" {
+		return 0, fmt.Errorf("missing type")
+	}
+	if e.Title == "" {
+		return 0, fmt.Errorf("missing title")
+	}
+	wiType, err := checkWorkItemType(ctx, client, project, e.Type)
+	if err != nil {
+		return 0, err
+	}
+
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names) // deterministic patch order
+
+	fields := []api.PatchField{{Op: "add", Path: "/fields/System.Title", Value: e.Title}}
+	for _, name := range names {
+		fields = append(fields, api.PatchField{Op: "add", Path: "/fields/" + name, Value: e.Fields[name]})
+	}
+	wi, err := client.CreateWorkItem(ctx, project, wiType, fields)
+	if err != nil {
+		return 0, err
+	}
+
+	if e.Parent > 0 {
+		if _, err := client.AddWorkItemRelation(ctx, project, wi.ID, api.RelHierarchyReverse, client.WorkItemAPIURL(e.Parent)); err != nil {
+			return wi.ID, fmt.Errorf("linking to parent %d: %w", e.Parent, err)
+		}
+	}
+	return wi.ID, nil
+}
+
+// printBulkResults renders a bulk operation summary in the current output format.
+func printBulkResults(results []bulkResult) error {
+	switch OutputFormat() {
+	case "json":
+		return printJSON(results)
+	case "plain":
+		for _, r := range results {
+			fmt.Printf("%d\t%s\n", r.ID, r.Result)
+		}
+	default: // table
+		fmt.Fprintf(os.Stdout, "%-8s %-10s %s\n", "ID", "Result", "Error")
+		fmt.Fprintln(os.Stdout, strings.Repeat("-", 80))
+		for _, r := range results {
+			fmt.Fprintf(os.Stdout, "%-8d %-10s %s\n", r.ID, r.Result, r.Error)
+		}
+	}
+	return nil
+}
+
+func init() {
+	// Bulk-transition flags
+	addProjectFlags(wiBulkTransitionCmd)
+	wiBulkTransitionCmd.Flags().String("saved-query", "", "Saved query ID or path (required)")
+	wiBulkTransitionCmd.Flags().String("to", "", "Target state (required)")
+	wiBulkTransitionCmd.Flags().String("reason", "", "Reason for the transition (default: workflow default)")
+	wiBulkTransitionCmd.Flags().Bool("dry-run", false, "Validate and report without updating anything")
+	wiBulkTransitionCmd.Flags().Int("top", 1000, "Maximum number of work items to transition")
+	wiBulkTransitionCmd.Flags().String("report", "", "Also write the report as CSV to this file")
+
+	// Bulk-update flags
+	addProjectFlags(wiBulkUpdateCmd)
+	wiBulkUpdateCmd.Flags().String("file", "", "JSON file of updates (required)")
+	wiBulkUpdateCmd.Flags().Bool("continue-on-error", true, "Keep going after a failed update")
+
+	// Bulk-create flags
+	addProjectFlags(wiBulkCreateCmd)
+	wiBulkCreateCmd.Flags().String("file", "", "JSON file of work items (required)")
+	wiBulkCreateCmd.Flags().Bool("continue-on-error", true, "Keep going after a failed entry")
+
+	workitemCmd.AddCommand(wiBulkTransitionCmd)
+	workitemCmd.AddCommand(wiBulkUpdateCmd)
+	workitemCmd.AddCommand(wiBulkCreateCmd)
+}
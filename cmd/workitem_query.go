@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gyurisc/adocli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// --- ado workitem query ---
+
+var wiQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Run a WIQL query",
+	Long: `Run an arbitrary WIQL query and show the matching work items.
+
+Provide the query inline or from a file:
+  ado workitem query --wiql "SELECT [System.Id] FROM WorkItems WHERE [Microsoft.VSTS.Common.Priority] = 1"
+  ado workitem query --wiql-file bugs.wiql`,
+	RunE: runWorkitemQuery,
+}
+
+func runWorkitemQuery(cmd *cobra.Command, args []string) error {
+	wiql, _ := cmd.Flags().GetString("wiql")
+	wiqlFile, _ := cmd.Flags().GetString("wiql-file")
+	top, _ := cmd.Flags().GetInt("top")
+
+	if wiqlFile != "" {
+		data, err := os.ReadFile(wiqlFile)
+		if err != nil {
+			return fmt.Errorf("reading WIQL file: %w", err)
+		}
+		wiql = string(data)
+	}
+	wiql = strings.TrimSpace(wiql)
+	if wiql == "" {
+		return fmt.Errorf("--wiql or --wiql-file is required")
+	}
+	if !strings.HasPrefix(strings.ToUpper(wiql), "SELECT") {
+		return fmt.Errorf("WIQL query must start with SELECT")
+	}
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	result, err := client.QueryByWiql(ctx, project, wiql, top)
+	if err != nil {
+		return fmt.Errorf("querying work items: %w", err)
+	}
+
+	ids := result.IDs()
+	if top > 0 && len(ids) > top {
+		ids = ids[:top]
+	}
+	return fetchAndPrintWorkItems(ctx, client, project, ids, nil)
+}
+
+// --- ado workitem run-query ---
+
+var wiRunQueryCmd = &cobra.Command{
+	Use:   "run-query <nameOrId>",
+	Short: "Run a saved query",
+	Long: `Run a saved (shared or personal) work item query by ID or path.
+
+  ado workitem run-query "Shared Queries/My Bugs"
+  ado workitem run-query 2b1e3f4c-0000-0000-0000-000000000000`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkitemRunQuery,
+}
+
+func runWorkitemRunQuery(cmd *cobra.Command, args []string) error {
+	top, _ := cmd.Flags().GetInt("top")
+
+	ctx := cmd.Context()
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return err
+	}
+
+	ids, err := runSavedQuery(ctx, client, project, args[0], top)
+	if err != nil {
+		return err
+	}
+	return fetchAndPrintWorkItems(ctx, client, project, ids, nil)
+}
+
+// runSavedQuery looks up a saved query by ID or path, runs its WIQL, and
+// returns up to top matching work item IDs.
+func runSavedQuery(ctx context.Context, client *api.Client, project, nameOrID string, top int) ([]int, error) {
+	query, err := client.GetSavedQuery(ctx, project, nameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching saved query %q: %w", nameOrID, err)
+	}
+	if query.IsFolder {
+		return nil, fmt.Errorf("%q is a query folder, not a query", query.Path)
+	}
+
+	result, err := client.QueryByWiql(ctx, project, query.Wiql, top)
+	if err != nil {
+		return nil, fmt.Errorf("running saved query %q: %w", query.Name, err)
+	}
+	ids := result.IDs()
+	if top > 0 && len(ids) > top {
+		ids = ids[:top]
+	}
+	return ids, nil
+}
+
+func init() {
+	// Query flags
+	addProjectFlags(wiQueryCmd)
+	supportsTemplate(wiQueryCmd)
+	wiQueryCmd.Flags().String("wiql", "", "WIQL query text")
+	wiQueryCmd.Flags().String("wiql-file", "", "Read the WIQL query from a file")
+	wiQueryCmd.Flags().Int("top", 20, "Maximum number of results")
+	wiQueryCmd.MarkFlagsMutuallyExclusive("wiql", "wiql-file")
+
+	// Run-query flags
+	addProjectFlags(wiRunQueryCmd)
+	supportsTemplate(wiRunQueryCmd)
+	wiRunQueryCmd.Flags().Int("top", 20, "Maximum number of results")
+
+	workitemCmd.AddCommand(wiQueryCmd)
+	workitemCmd.AddCommand(wiRunQueryCmd)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildWIQLTop(t *testing.T) {
+	tests := []struct {
+		name string
+		top  int
+		want string // "" means no TOP clause
+	}{
+		{"capped", 20, "SELECT TOP 20 [System.Id]"},
+		{"large cap", 5000, "SELECT TOP 5000 [System.Id]"},
+		{"no cap", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wiql := buildWIQL("Web", workItemFilter{Type: "Bug", Top: tt.top})
+			if tt.want == "" {
+				if strings.Contains(wiql, "TOP") {
+					t.Errorf("buildWIQL with Top %d = %q, want no TOP clause", tt.top, wiql)
+				}
+				return
+			}
+			if !strings.HasPrefix(wiql, tt.want) {
+				t.Errorf("buildWIQL with Top %d = %q, want prefix %q", tt.top, wiql, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ module github.com/gyurisc/adocli
 go 1.23.0
 
 require (
+	github.com/jmespath/go-jmespath v0.4.0
 	github.com/spf13/cobra v1.10.2
 	github.com/spf13/viper v1.21.0
 	github.com/zalando/go-keyring v0.2.6
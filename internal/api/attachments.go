@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// AttachmentRef is an uploaded work item attachment.
+type AttachmentRef struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// UploadAttachment uploads a file to the project's attachment store. The
+// returned URL can then be linked to a work item with AddAttachment.
+func (c *Client) UploadAttachment(ctx context.Context, project, filename string, data io.Reader) (*AttachmentRef, error) {
+	rawURL := c.ProjectURL(project, "wit/attachments?fileName="+url.QueryEscape(filename))
+	resp, err := c.doRaw(ctx, http.MethodPost, rawURL, "application/octet-stream", data)
+	if err != nil {
+		return nil, err
+	}
+	var ref AttachmentRef
+	if err := decodeOrClose(resp, &ref); err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+// AddAttachment links an uploaded attachment to a work item.
+func (c *Client) AddAttachment(ctx context.Context, project string, id int, attachmentURL, comment string) (*WorkItem, error) {
+	rel := WorkItemRelation{Rel: RelAttachedFile, URL: attachmentURL}
+	if comment != "" {
+		rel.Attributes = map[string]interface{}{"comment": comment}
+	}
+	fields := []PatchField{
+		{Op: "add", Path: "/relations/-", Value: rel},
+	}
+	return c.UpdateWorkItem(ctx, project, id, fields)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Build is a single build (pipeline run) as seen by the Build API. Status is
+// "notStarted", "inProgress", "cancelling", "postponed", or "completed".
+type Build struct {
+	ID            int         `json:"id"`
+	BuildNumber   string      `json:"buildNumber"`
+	Status        string      `json:"status"`
+	Result        string      `json:"result,omitempty"`
+	SourceBranch  string      `json:"sourceBranch"`
+	SourceVersion string      `json:"sourceVersion"`
+	QueueTime     string      `json:"queueTime"`
+	StartTime     string      `json:"startTime,omitempty"`
+	FinishTime    string      `json:"finishTime,omitempty"`
+	Definition    BuildDefRef `json:"definition"`
+}
+
+// BuildDefRef identifies the definition a build was run from.
+type BuildDefRef struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// BuildLog describes one log produced by a build (one per job or step).
+type BuildLog struct {
+	ID            int    `json:"id"`
+	Type          string `json:"type"`
+	URL           string `json:"url"`
+	LineCount     int    `json:"lineCount"`
+	CreatedOn     string `json:"createdOn"`
+	LastChangedOn string `json:"lastChangedOn"`
+}
+
+// GetBuild returns a single build.
+func (c *Client) GetBuild(ctx context.Context, project string, buildID int) (*Build, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("build/builds/%d", buildID))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var build Build
+	if err := decodeOrClose(resp, &build); err != nil {
+		return nil, err
+	}
+	return &build, nil
+}
+
+// GetBuildLogs lists the logs of a build, ordered by ID.
+func (c *Client) GetBuildLogs(ctx context.Context, project string, buildID int) ([]BuildLog, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("build/builds/%d/logs", buildID))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var page listPage[BuildLog]
+	if err := decodeOrClose(resp, &page); err != nil {
+		return nil, err
+	}
+	return page.Value, nil
+}
+
+// GetBuildLogLines returns the lines of a single build log.
+func (c *Client) GetBuildLogLines(ctx context.Context, project string, buildID, logID int) ([]string, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("build/builds/%d/logs/%d", buildID, logID))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading log: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, newError(resp.StatusCode, body)
+	}
+
+	text := strings.TrimSuffix(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
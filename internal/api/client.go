@@ -3,15 +3,39 @@ package api
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"math/rand"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
-const defaultAPIVersion = "7.1"
+const (
+	defaultAPIVersion = "7.1"
+	defaultUserAgent  = "adocli"
+	defaultMaxRetries = 3
+
+	// retryBaseDelay is the first backoff delay; it doubles on each attempt
+	// up to retryMaxDelay.
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// defaultAPIVersionOverrides pins endpoints that are only available as a
+// preview. Keys are path prefixes relative to _apis/.
+var defaultAPIVersionOverrides = map[string]string{
+	"policy/evaluations": "7.1-preview.1",
+}
 
 // Client is an Azure DevOps REST API client.
 type Client struct {
@@ -19,6 +43,31 @@ type Client struct {
 	pat        string
 	APIVersion string
 	HTTP       *http.Client
+
+	// APIVersionOverrides maps path prefixes (relative to _apis/, e.g.
+	// "policy/evaluations") to the api-version those endpoints need. The
+	// longest matching prefix wins; other paths use APIVersion.
+	APIVersionOverrides map[string]string
+
+	// MaxRetries is how many times an idempotent request is retried after an
+	// HTTP 429 or 503 response. Zero disables retries.
+	MaxRetries int
+
+	// UserAgent is sent with every request. Embedders can override it.
+	UserAgent string
+
+	// Debug, if set, receives a log line for every request and response.
+	// The Authorization header is never written.
+	Debug io.Writer
+
+	// RateLimit caps the number of requests sent per second, including
+	// retries. Zero or less disables the limit.
+	RateLimit float64
+	bucket    *tokenBucket
+
+	cacheMu       sync.Mutex
+	workItemTypes map[string][]WorkItemType // by project
+	repoIDs       map[string]string         // by lower-cased "project:repo", name or ID
 }
 
 // String returns a safe representation of the client that redacts the PAT.
@@ -34,6 +83,12 @@ func NewClient(org, pat string) *Client {
 		pat:        pat,
 		APIVersion: defaultAPIVersion,
 		HTTP:       &http.Client{},
+		MaxRetries: defaultMaxRetries,
+		UserAgent:  defaultUserAgent,
+		RateLimit:  defaultRateLimit,
+		bucket:     &tokenBucket{},
+
+		APIVersionOverrides: maps.Clone(defaultAPIVersionOverrides),
 	}
 }
 
@@ -44,7 +99,7 @@ func (c *Client) authHeader() string {
 }
 
 // do executes an HTTP request and returns the response.
-func (c *Client) do(method, path string, body interface{}) (*http.Response, error) {
+func (c *Client) do(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
 	var reqBody io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -56,24 +111,26 @@ func (c *Client) do(method, path string, body interface{}) (*http.Response, erro
 
 	url := fmt.Sprintf("%s/%s", c.BaseURL, path)
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Set("Authorization", c.authHeader())
 	req.Header.Set("Content-Type", "application/json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
 
-	// Append api-version query parameter.
+	// Append api-version query parameter unless the path pins one.
 	q := req.URL.Query()
-	q.Set("api-version", c.APIVersion)
+	if q.Get("api-version") == "" {
+		q.Set("api-version", c.apiVersionFor(req.URL.Path))
+	}
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.HTTP.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
-	}
-	return resp, nil
+	return c.send(req)
 }
 
 // decodeOrClose reads the response body into result. It always closes the body.
@@ -82,7 +139,7 @@ func decodeOrClose(resp *http.Response, result interface{}) error {
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return &Error{StatusCode: resp.StatusCode, Body: string(body)}
+		return newError(resp.StatusCode, body)
 	}
 
 	if result != nil {
@@ -91,9 +148,51 @@ func decodeOrClose(resp *http.Response, result interface{}) error {
 	return nil
 }
 
+// continuationHeader carries the token for the next page of a list response.
+const continuationHeader = "x-ms-continuationtoken"
+
+// listPage is the envelope Azure DevOps wraps list results in.
+type listPage[T any] struct {
+	Count int `json:"count"`
+	Value []T `json:"value"`
+}
+
+// getAllPages GETs a list endpoint and follows continuation tokens until the
+// server has no more pages or top items (if top > 0) have been collected.
+func getAllPages[T any](ctx context.Context, c *Client, rawURL string, top int) ([]T, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing URL: %w", err)
+	}
+
+	var all []T
+	for {
+		resp, err := c.doRaw(ctx, http.MethodGet, u.String(), "application/json", nil)
+		if err != nil {
+			return nil, err
+		}
+		token := resp.Header.Get(continuationHeader)
+		var page listPage[T]
+		if err := decodeOrClose(resp, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Value...)
+
+		if top > 0 && len(all) >= top {
+			return all[:top], nil
+		}
+		if token == "" {
+			return all, nil
+		}
+		q := u.Query()
+		q.Set("continuationToken", token)
+		u.RawQuery = q.Encode()
+	}
+}
+
 // Get performs a GET request and decodes the JSON response into result.
-func (c *Client) Get(path string, result interface{}) error {
-	resp, err := c.do(http.MethodGet, path, nil)
+func (c *Client) Get(ctx context.Context, path string, result interface{}) error {
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return err
 	}
@@ -101,8 +200,8 @@ func (c *Client) Get(path string, result interface{}) error {
 }
 
 // Post performs a POST request with a JSON body and decodes the response.
-func (c *Client) Post(path string, body, result interface{}) error {
-	resp, err := c.do(http.MethodPost, path, body)
+func (c *Client) Post(ctx context.Context, path string, body, result interface{}) error {
+	resp, err := c.do(ctx, http.MethodPost, path, body)
 	if err != nil {
 		return err
 	}
@@ -110,8 +209,8 @@ func (c *Client) Post(path string, body, result interface{}) error {
 }
 
 // Patch performs a PATCH request with a JSON body and decodes the response.
-func (c *Client) Patch(path string, body, result interface{}) error {
-	resp, err := c.do(http.MethodPatch, path, body)
+func (c *Client) Patch(ctx context.Context, path string, body, result interface{}) error {
+	resp, err := c.do(ctx, http.MethodPatch, path, body)
 	if err != nil {
 		return err
 	}
@@ -119,15 +218,42 @@ func (c *Client) Patch(path string, body, result interface{}) error {
 }
 
 // ProjectURL constructs a project-scoped API URL.
+// The project may be given by name or by ID; names are path-escaped.
 func (c *Client) ProjectURL(project, path string) string {
 	orgBase := strings.TrimSuffix(c.BaseURL, "/_apis")
-	return fmt.Sprintf("%s/%s/_apis/%s", orgBase, project, path)
+	return fmt.Sprintf("%s/%s/_apis/%s", orgBase, url.PathEscape(project), path)
+}
+
+// TeamURL constructs a team-scoped API URL. An empty team means the
+// project's default team.
+func (c *Client) TeamURL(project, team, path string) string {
+	if team == "" {
+		return c.ProjectURL(project, path)
+	}
+	orgBase := strings.TrimSuffix(c.BaseURL, "/_apis")
+	return fmt.Sprintf("%s/%s/%s/_apis/%s", orgBase, url.PathEscape(project), url.PathEscape(team), path)
 }
 
-// doRaw executes an HTTP request with a caller-specified full URL and content type.
-func (c *Client) doRaw(method, rawURL, contentType string, body interface{}) (*http.Response, error) {
+// WebURL constructs a browser URL for a project-scoped page, e.g. "_workitems/edit/42".
+func (c *Client) WebURL(project, path string) string {
+	orgBase := strings.TrimSuffix(c.BaseURL, "/_apis")
+	return fmt.Sprintf("%s/%s/%s", orgBase, url.PathEscape(project), path)
+}
+
+var guidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsGUID reports whether s looks like an Azure DevOps GUID (project, repository, or identity ID).
+func IsGUID(s string) bool {
+	return guidPattern.MatchString(s)
+}
+
+// doRaw executes an HTTP request with a caller-specified full URL and content
+// type. An io.Reader body is sent as-is; anything else is marshaled to JSON.
+func (c *Client) doRaw(ctx context.Context, method, rawURL, contentType string, body interface{}) (*http.Response, error) {
 	var reqBody io.Reader
-	if body != nil {
+	if r, ok := body.(io.Reader); ok {
+		reqBody = r
+	} else if body != nil {
 		data, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshaling request body: %w", err)
@@ -135,31 +261,203 @@ func (c *Client) doRaw(method, rawURL, contentType string, body interface{}) (*h
 		reqBody = bytes.NewReader(data)
 	}
 
-	req, err := http.NewRequest(method, rawURL, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Set("Authorization", c.authHeader())
 	req.Header.Set("Content-Type", contentType)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
 
+	// Callers may pin a version in rawURL; otherwise apply any override.
 	q := req.URL.Query()
-	q.Set("api-version", c.APIVersion)
+	if q.Get("api-version") == "" {
+		q.Set("api-version", c.apiVersionFor(req.URL.Path))
+	}
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.HTTP.Do(req)
+	return c.send(req)
+}
+
+// apiVersionFor returns the api-version for a request path, applying the
+// longest matching entry in APIVersionOverrides.
+func (c *Client) apiVersionFor(path string) string {
+	i := strings.Index(path, "/_apis/")
+	if i < 0 {
+		return c.APIVersion
+	}
+	rel := path[i+len("/_apis/"):]
+
+	version, best := c.APIVersion, -1
+	for prefix, v := range c.APIVersionOverrides {
+		if strings.HasPrefix(rel, prefix) && len(prefix) > best {
+			version, best = v, len(prefix)
+		}
+	}
+	return version
+}
+
+// send executes req, retrying throttled or unavailable responses to
+// idempotent requests with exponential backoff.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.bucket.wait(req.Context(), c.RateLimit); err != nil {
+			return nil, fmt.Errorf("executing request: %w", err)
+		}
+		start := time.Now()
+		resp, err := c.HTTP.Do(req)
+		c.logRequest(req, resp, err, time.Since(start))
+		if err != nil {
+			return nil, fmt.Errorf("executing request: %w", err)
+		}
+		if attempt >= c.MaxRetries || !shouldRetry(req, resp) {
+			return decompress(resp)
+		}
+
+		delay := retryDelay(resp, attempt)
+		resp.Body.Close()
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, fmt.Errorf("executing request: %w", req.Context().Err())
+		case <-timer.C:
+		}
+
+		// Rewind the body for the next attempt.
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewinding request body: %w", err)
+			}
+			req.Body = body
+		}
+	}
+}
+
+// decompress replaces a gzip-encoded response body with a reader of the
+// decoded content. Setting Accept-Encoding ourselves turns off net/http's
+// transparent decompression, so this has to be done by hand.
+func decompress(resp *http.Response) (*http.Response, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp, nil
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err == io.EOF {
+		// Empty body, e.g. 204 No Content.
+		return resp, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+		resp.Body.Close()
+		return nil, fmt.Errorf("decompressing response: %w", err)
 	}
+	resp.Body = gzipBody{zr, resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
 	return resp, nil
 }
 
-// Error represents an error response from the Azure DevOps API.
+// gzipBody reads decoded content and closes the underlying response body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
+
+// logRequest writes one request/response pair to c.Debug, if set.
+func (c *Client) logRequest(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	if c.Debug == nil {
+		return
+	}
+	fmt.Fprintf(c.Debug, "> %s %s\n", req.Method, req.URL.Redacted())
+	fmt.Fprintf(c.Debug, "> Authorization: [REDACTED]\n")
+	elapsed = elapsed.Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(c.Debug, "< error after %s: %v\n", elapsed, err)
+		return
+	}
+	fmt.Fprintf(c.Debug, "< %s (%s)\n", resp.Status, elapsed)
+}
+
+// shouldRetry reports whether a response is worth retrying. Only GETs and
+// WIQL queries (a read-only POST) are retried, so a retry can never apply a
+// change twice.
+func shouldRetry(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet:
+		return true
+	case http.MethodPost:
+		return strings.HasSuffix(req.URL.Path, "/wit/wiql")
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before the next attempt. A Retry-After
+// header (in seconds or as an HTTP date) wins; otherwise the delay grows
+// exponentially with up to 50% jitter.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(ra); err == nil {
+			if d := time.Until(t); d > 0 {
+				return d
+			}
+			return 0
+		}
+	}
+
+	delay := retryBaseDelay << attempt
+	if delay <= 0 || delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// Error represents an error response from the Azure DevOps API. Message,
+// TypeKey, and ErrorCode are parsed from the standard error envelope when the
+// body has one; Body always holds the raw response.
 type Error struct {
 	StatusCode int
 	Body       string
+
+	Message   string
+	TypeKey   string
+	ErrorCode int
+}
+
+// newError builds an Error from a response status and body.
+func newError(statusCode int, body []byte) *Error {
+	e := &Error{StatusCode: statusCode, Body: string(body)}
+	var envelope struct {
+		Message   string `json:"message"`
+		TypeKey   string `json:"typeKey"`
+		ErrorCode int    `json:"errorCode"`
+	}
+	if json.Unmarshal(body, &envelope) == nil {
+		e.Message = envelope.Message
+		e.TypeKey = envelope.TypeKey
+		e.ErrorCode = envelope.ErrorCode
+	}
+	return e
 }
 
 func (e *Error) Error() string {
+	if e.Message != "" {
+		return "Azure DevOps: " + e.Message
+	}
 	return fmt.Sprintf("Azure DevOps API error (HTTP %d): %s", e.StatusCode, e.Body)
 }
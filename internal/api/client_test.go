@@ -0,0 +1,24 @@
+package api
+
+import "testing"
+
+func TestProjectURL(t *testing.T) {
+	c := NewClient("contoso", "pat")
+
+	tests := []struct {
+		name    string
+		project string
+		want    string
+	}{
+		{"name", "Web", "https://dev.azure.com/contoso/Web/_apis/git/repositories"},
+		{"spaced name", "My Project", "https://dev.azure.com/contoso/My%20Project/_apis/git/repositories"},
+		{"guid", "6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c", "https://dev.azure.com/contoso/6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c/_apis/git/repositories"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.ProjectURL(tt.project, "git/repositories"); got != tt.want {
+				t.Errorf("ProjectURL(%q) = %q, want %q", tt.project, got, tt.want)
+			}
+		})
+	}
+}
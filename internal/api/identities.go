@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type identity struct {
+	ID                  string `json:"id"`
+	ProviderDisplayName string `json:"providerDisplayName"`
+	Properties          struct {
+		Account struct {
+			Value string `json:"$value"`
+		} `json:"Account"`
+	} `json:"properties"`
+}
+
+type identityList struct {
+	Count int        `json:"count"`
+	Value []identity `json:"value"`
+}
+
+// identitiesURL returns the URL of the identities endpoint. On Azure DevOps
+// Services identities live on the vssps host; on Azure DevOps Server they are
+// served from the collection itself.
+func (c *Client) identitiesURL() string {
+	base := c.BaseURL
+	if strings.HasPrefix(base, "https://dev.azure.com/") {
+		base = "https://vssps.dev.azure.com/" + strings.TrimPrefix(base, "https://dev.azure.com/")
+	}
+	return base + "/identities"
+}
+
+// ResolveIdentity looks up a user by email, account name, or display name and
+// returns their identity. Input that is already a GUID is returned as-is.
+func (c *Client) ResolveIdentity(ctx context.Context, query string) (*IdentityRef, error) {
+	if IsGUID(query) {
+		return &IdentityRef{ID: query}, nil
+	}
+
+	u, err := url.Parse(c.identitiesURL())
+	if err != nil {
+		return nil, fmt.Errorf("parsing URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("searchFilter", "General")
+	q.Set("filterValue", query)
+	q.Set("queryMembership", "None")
+	u.RawQuery = q.Encode()
+
+	resp, err := c.doRaw(ctx, http.MethodGet, u.String(), "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result identityList
+	if err := decodeOrClose(resp, &result); err != nil {
+		return nil, err
+	}
+
+	switch len(result.Value) {
+	case 0:
+		return nil, fmt.Errorf("no identity found for %q", query)
+	case 1:
+		id := result.Value[0]
+		return &IdentityRef{ID: id.ID, DisplayName: id.ProviderDisplayName, UniqueName: id.Properties.Account.Value}, nil
+	default:
+		names := make([]string, 0, len(result.Value))
+		for _, id := range result.Value {
+			names = append(names, fmt.Sprintf("%s <%s>", id.ProviderDisplayName, id.Properties.Account.Value))
+		}
+		return nil, fmt.Errorf("%q matches several identities: %s", query, strings.Join(names, ", "))
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// PullRequestIteration is one push of new commits to a pull request.
+// CommonRefCommit is the merge base of the source and target commits.
+type PullRequestIteration struct {
+	ID              int           `json:"id"`
+	Description     string        `json:"description,omitempty"`
+	Author          *IdentityRef  `json:"author,omitempty"`
+	CreatedDate     string        `json:"createdDate,omitempty"`
+	SourceRefCommit *GitCommitRef `json:"sourceRefCommit,omitempty"`
+	TargetRefCommit *GitCommitRef `json:"targetRefCommit,omitempty"`
+	CommonRefCommit *GitCommitRef `json:"commonRefCommit,omitempty"`
+}
+
+// PullRequestChange is a single file change in a pull request iteration.
+// ChangeType is a comma-separated list such as "add", "edit", "delete", or
+// "edit, rename".
+type PullRequestChange struct {
+	ChangeID     int         `json:"changeId"`
+	ChangeType   string      `json:"changeType"`
+	Item         ChangedItem `json:"item"`
+	OriginalPath string      `json:"originalPath,omitempty"`
+}
+
+// ChangedItem is the file or folder a change applies to.
+type ChangedItem struct {
+	Path     string `json:"path"`
+	IsFolder bool   `json:"isFolder,omitempty"`
+}
+
+type pullRequestIterationList struct {
+	Count int                    `json:"count"`
+	Value []PullRequestIteration `json:"value"`
+}
+
+type pullRequestChanges struct {
+	ChangeEntries []PullRequestChange `json:"changeEntries"`
+	NextSkip      int                 `json:"nextSkip"`
+	NextTop       int                 `json:"nextTop"`
+}
+
+// GetPullRequestIterations returns the iterations of a pull request, oldest first.
+func (c *Client) GetPullRequestIterations(ctx context.Context, project, repoID string, prID int) ([]PullRequestIteration, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests/%d/iterations", repoID, prID))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result pullRequestIterationList
+	if err := decodeOrClose(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// GetPullRequestChanges returns the changes in an iteration compared to the
+// pull request's target branch. Results are paged until every change is read.
+func (c *Client) GetPullRequestChanges(ctx context.Context, project, repoID string, prID, iterationID int) ([]PullRequestChange, error) {
+	var changes []PullRequestChange
+	skip := 0
+	for {
+		rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests/%d/iterations/%d/changes?$compareTo=0&$top=2000&$skip=%d",
+			repoID, prID, iterationID, skip))
+
+		resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+		if err != nil {
+			return nil, err
+		}
+		var page pullRequestChanges
+		if err := decodeOrClose(resp, &page); err != nil {
+			return nil, err
+		}
+		changes = append(changes, page.ChangeEntries...)
+
+		if page.NextTop == 0 || page.NextSkip <= skip {
+			return changes, nil
+		}
+		skip = page.NextSkip
+	}
+}
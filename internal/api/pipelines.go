@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Pipeline is an Azure Pipelines pipeline definition.
+type Pipeline struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Folder   string `json:"folder"`
+	Revision int    `json:"revision"`
+	URL      string `json:"url"`
+}
+
+// PipelineRun is a single run of a pipeline. State is "inProgress",
+// "canceling", or "completed"; Result is set once completed ("succeeded",
+// "failed", "canceled", or "unknown").
+type PipelineRun struct {
+	ID           int         `json:"id"`
+	Name         string      `json:"name"`
+	State        string      `json:"state"`
+	Result       string      `json:"result,omitempty"`
+	CreatedDate  string      `json:"createdDate"`
+	FinishedDate string      `json:"finishedDate,omitempty"`
+	URL          string      `json:"url"`
+	Pipeline     PipelineRef `json:"pipeline"`
+	Links        runLinks    `json:"_links"`
+}
+
+// PipelineRef identifies the pipeline a run belongs to.
+type PipelineRef struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type runLinks struct {
+	Web struct {
+		Href string `json:"href"`
+	} `json:"web"`
+}
+
+// WebURL returns the browser URL of the run.
+func (r *PipelineRun) WebURL() string {
+	return r.Links.Web.Href
+}
+
+// RunParams holds the options for starting a pipeline run.
+type RunParams struct {
+	Branch             string            // Branch of the pipeline's own repository; default branch if empty
+	TemplateParameters map[string]string // Values for the pipeline's template parameters
+}
+
+// ListPipelines returns up to top pipelines in a project (all if top <= 0).
+func (c *Client) ListPipelines(ctx context.Context, project string, top int) ([]Pipeline, error) {
+	rawURL := c.ProjectURL(project, "pipelines")
+	if top > 0 {
+		rawURL += fmt.Sprintf("?$top=%d", top)
+	}
+	return getAllPages[Pipeline](ctx, c, rawURL, top)
+}
+
+// RunPipeline queues a new run of a pipeline.
+func (c *Client) RunPipeline(ctx context.Context, project string, pipelineID int, params RunParams) (*PipelineRun, error) {
+	body := map[string]interface{}{}
+	if params.Branch != "" {
+		body["resources"] = map[string]interface{}{
+			"repositories": map[string]interface{}{
+				"self": map[string]string{"refName": params.Branch},
+			},
+		}
+	}
+	if len(params.TemplateParameters) > 0 {
+		body["templateParameters"] = params.TemplateParameters
+	}
+
+	rawURL := c.ProjectURL(project, fmt.Sprintf("pipelines/%d/runs", pipelineID))
+	resp, err := c.doRaw(ctx, http.MethodPost, rawURL, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	var run PipelineRun
+	if err := decodeOrClose(resp, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetPipelineRun returns a single run of a pipeline.
+func (c *Client) GetPipelineRun(ctx context.Context, project string, pipelineID, runID int) (*PipelineRun, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("pipelines/%d/runs/%d", pipelineID, runID))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var run PipelineRun
+	if err := decodeOrClose(resp, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// PolicyEvaluation is the status of one branch policy for a pull request.
+// Status is one of queued, running, approved, rejected, notApplicable, or broken.
+type PolicyEvaluation struct {
+	EvaluationID  string              `json:"evaluationId"`
+	Status        string              `json:"status"`
+	Configuration PolicyConfiguration `json:"configuration"`
+}
+
+// PolicyConfiguration describes the policy being evaluated.
+type PolicyConfiguration struct {
+	ID         int                    `json:"id"`
+	IsBlocking bool                   `json:"isBlocking"`
+	IsEnabled  bool                   `json:"isEnabled"`
+	Type       PolicyType             `json:"type"`
+	Settings   map[string]interface{} `json:"settings"`
+}
+
+// PolicyType identifies the kind of policy (e.g. "Minimum number of reviewers").
+type PolicyType struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+// DisplayName returns the policy's configured name, falling back to its type name.
+func (e PolicyEvaluation) DisplayName() string {
+	if name, ok := e.Configuration.Settings["displayName"].(string); ok && name != "" {
+		return name
+	}
+	return e.Configuration.Type.DisplayName
+}
+
+type policyEvaluationList struct {
+	Count int                `json:"count"`
+	Value []PolicyEvaluation `json:"value"`
+}
+
+// GetPolicyEvaluations returns the branch policy evaluations for a pull request.
+// The artifact ID embeds the project GUID, so a project name is resolved first.
+func (c *Client) GetPolicyEvaluations(ctx context.Context, project string, prID int) ([]PolicyEvaluation, error) {
+	projectID, err := c.ProjectID(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("resolving project ID: %w", err)
+	}
+
+	artifactID := fmt.Sprintf("vstfs:///CodeReview/CodeReviewId/%s/%d", projectID, prID)
+	path := fmt.Sprintf("policy/evaluations?artifactId=%s", url.QueryEscape(artifactID))
+	rawURL := c.ProjectURL(project, path)
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result policyEvaluationList
+	if err := decodeOrClose(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
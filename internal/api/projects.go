@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"net/url"
+)
+
+// Project represents an Azure DevOps team project.
+type Project struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	URL         string `json:"url"`
+}
+
+// ListProjects returns every project in the organization the caller can see.
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	return getAllPages[Project](ctx, c, c.BaseURL+"/projects", 0)
+}
+
+// GetProject retrieves a project by name or ID.
+func (c *Client) GetProject(ctx context.Context, project string) (*Project, error) {
+	var p Project
+	if err := c.Get(ctx, "projects/"+url.PathEscape(project), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ProjectID returns the GUID of a project given its name or ID.
+func (c *Client) ProjectID(ctx context.Context, project string) (string, error) {
+	if IsGUID(project) {
+		return project, nil
+	}
+	p, err := c.GetProject(ctx, project)
+	if err != nil {
+		return "", err
+	}
+	return p.ID, nil
+}
@@ -1,10 +1,12 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // PullRequest represents an Azure DevOps Git pull request.
@@ -22,13 +24,19 @@ type PullRequest struct {
 	Repository   PRRepository `json:"repository"`
 	Reviewers    []Reviewer   `json:"reviewers"`
 	URL          string       `json:"url"`
+	WebURL       string       `json:"webUrl,omitempty"` // set by callers via PullRequestWebURL
+
+	LastMergeSourceCommit *GitCommitRef      `json:"lastMergeSourceCommit,omitempty"`
+	CompletionOptions     *CompletionOptions `json:"completionOptions,omitempty"`
 }
 
-// IdentityRef represents a user identity in Azure DevOps.
+// IdentityRef represents a user identity in Azure DevOps. IsRequired is only
+// used when adding reviewers to a new pull request.
 type IdentityRef struct {
 	ID          string `json:"id"`
 	DisplayName string `json:"displayName"`
 	UniqueName  string `json:"uniqueName"`
+	IsRequired  bool   `json:"isRequired,omitempty"`
 }
 
 // Reviewer represents a pull request reviewer with their vote.
@@ -37,6 +45,7 @@ type Reviewer struct {
 	DisplayName string `json:"displayName"`
 	UniqueName  string `json:"uniqueName"`
 	Vote        int    `json:"vote"`
+	IsRequired  bool   `json:"isRequired,omitempty"`
 }
 
 // PRRepository is the repository info embedded in a pull request response.
@@ -47,27 +56,25 @@ type PRRepository struct {
 
 // Repository represents a Git repository in Azure DevOps.
 type Repository struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-	URL  string `json:"url"`
-}
-
-type pullRequestList struct {
-	Count int           `json:"count"`
-	Value []PullRequest `json:"value"`
-}
-
-type repositoryList struct {
-	Count int          `json:"count"`
-	Value []Repository `json:"value"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	URL           string `json:"url"`
+	DefaultBranch string `json:"defaultBranch,omitempty"`
+	Size          int64  `json:"size"`
+	RemoteURL     string `json:"remoteUrl,omitempty"`
+	SSHURL        string `json:"sshUrl,omitempty"`
+	WebURL        string `json:"webUrl,omitempty"`
+	IsDisabled    bool   `json:"isDisabled"`
 }
 
 // PullRequestQuery holds search criteria for listing pull requests.
 type PullRequestQuery struct {
-	Status   string
-	Creator  string
-	Reviewer string
-	Top      int
+	Status        string
+	Creator       string
+	Reviewer      string
+	SourceRefName string // full ref, e.g. refs/heads/feature/x
+	TargetRefName string // full ref, e.g. refs/heads/main
+	Top           int
 }
 
 // CreatePRInput holds the fields for creating a new pull request.
@@ -80,28 +87,85 @@ type CreatePRInput struct {
 	Reviewers     []IdentityRef `json:"reviewers,omitempty"`
 }
 
+// WebAPITagDefinition is a label attached to a pull request.
+type WebAPITagDefinition struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+// GitCommitRef identifies a commit. Author, Committer, and Comment are only
+// filled in by endpoints that list commits.
+type GitCommitRef struct {
+	CommitID  string       `json:"commitId"`
+	Author    *GitUserDate `json:"author,omitempty"`
+	Committer *GitUserDate `json:"committer,omitempty"`
+	Comment   string       `json:"comment,omitempty"`
+	URL       string       `json:"url,omitempty"`
+}
+
+// CompletionOptions controls how a pull request is merged when completed.
+// MergeStrategy is one of "noFastForward", "squash", "rebase", or "rebaseMerge".
+type CompletionOptions struct {
+	MergeStrategy      string `json:"mergeStrategy,omitempty"`
+	DeleteSourceBranch bool   `json:"deleteSourceBranch,omitempty"`
+	MergeCommitMessage string `json:"mergeCommitMessage,omitempty"`
+}
+
 // ConnectionData represents the response from the connectionData endpoint.
 type ConnectionData struct {
 	AuthenticatedUser IdentityRef `json:"authenticatedUser"`
 }
 
+// PullRequestWebURL returns the browser URL for a pull request.
+func (c *Client) PullRequestWebURL(project, repoName string, prID int) string {
+	return c.WebURL(project, fmt.Sprintf("_git/%s/pullrequest/%d", url.PathEscape(repoName), prID))
+}
+
+// PullRequestArtifactURL returns the vstfs artifact URI that identifies a pull
+// request in work item links. projectID and repoID must be GUIDs.
+func PullRequestArtifactURL(projectID, repoID string, prID int) string {
+	return fmt.Sprintf("vstfs:///Git/PullRequestId/%s%%2F%s%%2F%d", projectID, repoID, prID)
+}
+
 // ListRepositories returns all Git repositories in a project.
-func (c *Client) ListRepositories(project string) ([]Repository, error) {
-	rawURL := c.ProjectURL(project, "git/repositories")
-	resp, err := c.doRaw(http.MethodGet, rawURL, "application/json", nil)
+func (c *Client) ListRepositories(ctx context.Context, project string) ([]Repository, error) {
+	return getAllPages[Repository](ctx, c, c.ProjectURL(project, "git/repositories"), 0)
+}
+
+// RepositoryID maps a repository name or ID to its ID within the project.
+// It returns "" if the project has no such repository. Repositories are
+// listed once per project and cached for the lifetime of the client.
+func (c *Client) RepositoryID(ctx context.Context, project, repo string) (string, error) {
+	key := func(r string) string { return strings.ToLower(project + ":" + r) }
+	c.cacheMu.Lock()
+	id, ok := c.repoIDs[key(repo)]
+	c.cacheMu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	repos, err := c.ListRepositories(ctx, project)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	var result repositoryList
-	if err := decodeOrClose(resp, &result); err != nil {
-		return nil, err
+
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	if c.repoIDs == nil {
+		c.repoIDs = map[string]string{}
+	}
+	for _, r := range repos {
+		c.repoIDs[key(r.Name)] = r.ID
+		c.repoIDs[key(r.ID)] = r.ID
 	}
-	return result.Value, nil
+	return c.repoIDs[key(repo)], nil
 }
 
 // ListPullRequests lists pull requests, optionally scoped to a repository.
-// If repoID is empty, lists across all repositories in the project.
-func (c *Client) ListPullRequests(project, repoID string, query PullRequestQuery) ([]PullRequest, error) {
+// If repoID is empty, lists across all repositories in the project. Results
+// are paged until query.Top pull requests are collected.
+func (c *Client) ListPullRequests(ctx context.Context, project, repoID string, query PullRequestQuery) ([]PullRequest, error) {
 	var path string
 	if repoID != "" {
 		path = fmt.Sprintf("git/repositories/%s/pullrequests", repoID)
@@ -124,26 +188,38 @@ func (c *Client) ListPullRequests(project, repoID string, query PullRequestQuery
 	if query.Reviewer != "" {
 		q.Set("searchCriteria.reviewerId", query.Reviewer)
 	}
+	if query.SourceRefName != "" {
+		q.Set("searchCriteria.sourceRefName", query.SourceRefName)
+	}
+	if query.TargetRefName != "" {
+		q.Set("searchCriteria.targetRefName", query.TargetRefName)
+	}
 	if query.Top > 0 {
 		q.Set("$top", strconv.Itoa(query.Top))
 	}
 	u.RawQuery = q.Encode()
 
-	resp, err := c.doRaw(http.MethodGet, u.String(), "application/json", nil)
+	return getAllPages[PullRequest](ctx, c, u.String(), query.Top)
+}
+
+// GetPullRequest retrieves a single pull request by ID.
+func (c *Client) GetPullRequest(ctx context.Context, project string, id int) (*PullRequest, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/pullrequests/%d", id))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
 	if err != nil {
 		return nil, err
 	}
-	var result pullRequestList
-	if err := decodeOrClose(resp, &result); err != nil {
+	var pr PullRequest
+	if err := decodeOrClose(resp, &pr); err != nil {
 		return nil, err
 	}
-	return result.Value, nil
+	return &pr, nil
 }
 
-// GetPullRequest retrieves a single pull request by ID.
-func (c *Client) GetPullRequest(project string, id int) (*PullRequest, error) {
-	rawURL := c.ProjectURL(project, fmt.Sprintf("git/pullrequests/%d", id))
-	resp, err := c.doRaw(http.MethodGet, rawURL, "application/json", nil)
+// CreatePullRequest creates a new pull request in the given repository.
+func (c *Client) CreatePullRequest(ctx context.Context, project, repoID string, input CreatePRInput) (*PullRequest, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests", repoID))
+	resp, err := c.doRaw(ctx, http.MethodPost, rawURL, "application/json", input)
 	if err != nil {
 		return nil, err
 	}
@@ -154,10 +230,60 @@ func (c *Client) GetPullRequest(project string, id int) (*PullRequest, error) {
 	return &pr, nil
 }
 
-// CreatePullRequest creates a new pull request in the given repository.
-func (c *Client) CreatePullRequest(project, repoID string, input CreatePRInput) (*PullRequest, error) {
-	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests", repoID))
-	resp, err := c.doRaw(http.MethodPost, rawURL, "application/json", input)
+// CompletePullRequest completes (merges) a pull request using the given options.
+// lastMergeSource is the PR's latest source commit, which the API requires.
+func (c *Client) CompletePullRequest(ctx context.Context, project, repoID string, prID int, lastMergeSource *GitCommitRef, opts CompletionOptions) (*PullRequest, error) {
+	if lastMergeSource == nil {
+		return nil, fmt.Errorf("pull request %d has no source commit", prID)
+	}
+
+	body := map[string]interface{}{
+		"status":                "completed",
+		"lastMergeSourceCommit": lastMergeSource,
+		"completionOptions":     opts,
+	}
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests/%d", repoID, prID))
+	resp, err := c.doRaw(ctx, http.MethodPatch, rawURL, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	var pr PullRequest
+	if err := decodeOrClose(resp, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// SetAutoComplete turns on auto-complete for a pull request on behalf of
+// userID: the PR is merged with opts as soon as its policies pass.
+func (c *Client) SetAutoComplete(ctx context.Context, project, repoID string, prID int, userID string, opts CompletionOptions) (*PullRequest, error) {
+	fields := map[string]interface{}{
+		"autoCompleteSetBy": IdentityRef{ID: userID},
+		"completionOptions": opts,
+	}
+	return c.UpdatePullRequest(ctx, project, repoID, prID, fields)
+}
+
+// SetPullRequestStatus changes a pull request's status ("active" or "abandoned").
+func (c *Client) SetPullRequestStatus(ctx context.Context, project, repoID string, prID int, status string) (*PullRequest, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests/%d", repoID, prID))
+	body := map[string]string{"status": status}
+	resp, err := c.doRaw(ctx, http.MethodPatch, rawURL, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	var pr PullRequest
+	if err := decodeOrClose(resp, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// UpdatePullRequest patches a pull request with the given fields (e.g.
+// "title", "description", "isDraft"). Fields not present are left unchanged.
+func (c *Client) UpdatePullRequest(ctx context.Context, project, repoID string, prID int, fields map[string]interface{}) (*PullRequest, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests/%d", repoID, prID))
+	resp, err := c.doRaw(ctx, http.MethodPatch, rawURL, "application/json", fields)
 	if err != nil {
 		return nil, err
 	}
@@ -168,23 +294,112 @@ func (c *Client) CreatePullRequest(project, repoID string, input CreatePRInput)
 	return &pr, nil
 }
 
+// AddPullRequestLabel attaches a label to a pull request, creating the label if needed.
+func (c *Client) AddPullRequestLabel(ctx context.Context, project, repoID string, prID int, name string) (*WebAPITagDefinition, error) {
+	path := fmt.Sprintf("git/repositories/%s/pullrequests/%d/labels", repoID, prID)
+	rawURL := c.ProjectURL(project, path)
+	body := map[string]string{"name": name}
+	resp, err := c.doRaw(ctx, http.MethodPost, rawURL, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	var label WebAPITagDefinition
+	if err := decodeOrClose(resp, &label); err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
 // VotePullRequest sets a reviewer's vote on a pull request.
-func (c *Client) VotePullRequest(project, repoID string, prID int, reviewerID string, vote int) error {
+func (c *Client) VotePullRequest(ctx context.Context, project, repoID string, prID int, reviewerID string, vote int) error {
 	path := fmt.Sprintf("git/repositories/%s/pullrequests/%d/reviewers/%s", repoID, prID, reviewerID)
 	rawURL := c.ProjectURL(project, path)
 	body := map[string]int{"vote": vote}
-	resp, err := c.doRaw(http.MethodPut, rawURL, "application/json", body)
+	resp, err := c.doRaw(ctx, http.MethodPut, rawURL, "application/json", body)
+	if err != nil {
+		return err
+	}
+	return decodeOrClose(resp, nil)
+}
+
+// AddPullRequestReviewer adds a reviewer to a pull request, optionally as required.
+func (c *Client) AddPullRequestReviewer(ctx context.Context, project, repoID string, prID int, reviewerID string, required bool) (*Reviewer, error) {
+	path := fmt.Sprintf("git/repositories/%s/pullrequests/%d/reviewers/%s", repoID, prID, reviewerID)
+	rawURL := c.ProjectURL(project, path)
+	body := map[string]interface{}{"vote": 0, "isRequired": required}
+	resp, err := c.doRaw(ctx, http.MethodPut, rawURL, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	var r Reviewer
+	if err := decodeOrClose(resp, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// RemovePullRequestReviewer removes a reviewer from a pull request.
+func (c *Client) RemovePullRequestReviewer(ctx context.Context, project, repoID string, prID int, reviewerID string) error {
+	path := fmt.Sprintf("git/repositories/%s/pullrequests/%d/reviewers/%s", repoID, prID, reviewerID)
+	rawURL := c.ProjectURL(project, path)
+	resp, err := c.doRaw(ctx, http.MethodDelete, rawURL, "application/json", nil)
 	if err != nil {
 		return err
 	}
 	return decodeOrClose(resp, nil)
 }
 
+// resourceRef is a lightweight reference returned by some Git endpoints.
+type resourceRef struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+type resourceRefList struct {
+	Count int           `json:"count"`
+	Value []resourceRef `json:"value"`
+}
+
+// GetPullRequestWorkItems returns the IDs of the work items linked to a pull request.
+func (c *Client) GetPullRequestWorkItems(ctx context.Context, project, repoID string, prID int) ([]int, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests/%d/workitems", repoID, prID))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result resourceRefList
+	if err := decodeOrClose(resp, &result); err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(result.Value))
+	for _, ref := range result.Value {
+		id, err := strconv.Atoi(ref.ID)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected work item ID %q", ref.ID)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 // GetConnectionData returns information about the authenticated user.
-func (c *Client) GetConnectionData() (*ConnectionData, error) {
+func (c *Client) GetConnectionData(ctx context.Context) (*ConnectionData, error) {
 	var data ConnectionData
-	if err := c.Get("connectionData", &data); err != nil {
+	if err := c.Get(ctx, "connectionData", &data); err != nil {
 		return nil, err
 	}
 	return &data, nil
 }
+
+// GitUserDate is the author or committer of a commit.
+type GitUserDate struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Date  string `json:"date"`
+}
+
+// GetPullRequestCommits returns the commits in a pull request, newest first.
+func (c *Client) GetPullRequestCommits(ctx context.Context, project, repoID string, prID int) ([]GitCommitRef, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests/%d/commits", repoID, prID))
+	return getAllPages[GitCommitRef](ctx, c, rawURL, 0)
+}
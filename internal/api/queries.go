@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// QueryHierarchyItem is a saved work item query or query folder.
+type QueryHierarchyItem struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	IsFolder  bool   `json:"isFolder"`
+	IsPublic  bool   `json:"isPublic"`
+	QueryType string `json:"queryType"`
+	Wiql      string `json:"wiql"`
+	URL       string `json:"url"`
+}
+
+// GetSavedQuery retrieves a saved query (or folder) by ID or path, including its WIQL text.
+// Paths look like "Shared Queries/My Bugs"; the returned item carries the resolved ID.
+func (c *Client) GetSavedQuery(ctx context.Context, project, idOrPath string) (*QueryHierarchyItem, error) {
+	segments := strings.Split(strings.Trim(idOrPath, "/"), "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	rawURL := c.ProjectURL(project, fmt.Sprintf("wit/queries/%s?$expand=wiql", strings.Join(segments, "/")))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var q QueryHierarchyItem
+	if err := decodeOrClose(resp, &q); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
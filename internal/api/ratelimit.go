@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// defaultRateLimit is the default client-side request rate, in requests per
+// second. It keeps batch operations under Azure DevOps's per-user throttling.
+const defaultRateLimit = 10
+
+// tokenBucket is a token-bucket limiter whose rate is passed on each call, so
+// changes to Client.RateLimit take effect immediately. The bucket holds up to
+// one second's worth of tokens.
+type tokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// wait blocks until a request may be sent at rate requests per second, or
+// until ctx is done. A rate <= 0 means no limit.
+func (b *tokenBucket) wait(ctx context.Context, rate float64) error {
+	if b == nil || rate <= 0 {
+		return nil
+	}
+	burst := math.Max(1, rate)
+
+	b.mu.Lock()
+	now := time.Now()
+	if b.last.IsZero() {
+		b.tokens = burst
+	} else {
+		b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	}
+	b.last = now
+	// Take a token now, going into debt if none is available; the debt is
+	// paid off by sleeping.
+	b.tokens--
+	delay := time.Duration(-b.tokens / rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// DeletedRepository represents a soft-deleted repository in the project recycle bin.
+type DeletedRepository struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	DeletedBy   IdentityRef `json:"deletedBy"`
+	DeletedDate string      `json:"deletedDate"`
+}
+
+type deletedRepositoryList struct {
+	Count int                 `json:"count"`
+	Value []DeletedRepository `json:"value"`
+}
+
+// GetRepository retrieves a Git repository by name or ID.
+func (c *Client) GetRepository(ctx context.Context, project, repo string) (*Repository, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s", url.PathEscape(repo)))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var r Repository
+	if err := decodeOrClose(resp, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// GetRepositoryRaw retrieves a Git repository as the undecoded JSON object,
+// including fields not mapped onto Repository.
+func (c *Client) GetRepositoryRaw(ctx context.Context, project, repo string) (map[string]interface{}, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s", url.PathEscape(repo)))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var r map[string]interface{}
+	if err := decodeOrClose(resp, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DeleteRepository deletes a Git repository. Azure DevOps keeps deleted
+// repositories in the recycle bin for 30 days, during which they can be restored.
+func (c *Client) DeleteRepository(ctx context.Context, project, repoID string) error {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s", repoID))
+	resp, err := c.doRaw(ctx, http.MethodDelete, rawURL, "application/json", nil)
+	if err != nil {
+		return err
+	}
+	return decodeOrClose(resp, nil)
+}
+
+// ListDeletedRepositories returns the soft-deleted repositories in the project recycle bin.
+func (c *Client) ListDeletedRepositories(ctx context.Context, project string) ([]DeletedRepository, error) {
+	rawURL := c.ProjectURL(project, "git/recycleBin/repositories")
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result deletedRepositoryList
+	if err := decodeOrClose(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// RestoreRepository restores a soft-deleted repository from the recycle bin.
+func (c *Client) RestoreRepository(ctx context.Context, project, repoID string) (*Repository, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/recycleBin/repositories/%s", repoID))
+	body := map[string]bool{"deleted": false}
+	resp, err := c.doRaw(ctx, http.MethodPatch, rawURL, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	var repo Repository
+	if err := decodeOrClose(resp, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// GitRef is a Git reference (branch or tag) in a repository.
+type GitRef struct {
+	Name     string      `json:"name"`
+	ObjectID string      `json:"objectId"`
+	Creator  IdentityRef `json:"creator"`
+	URL      string      `json:"url"`
+}
+
+// ListBranches returns the branches of a repository. If prefix is non-empty
+// only branches whose name (without refs/heads/) starts with it are returned.
+func (c *Client) ListBranches(ctx context.Context, project, repoID, prefix string) ([]GitRef, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/refs?filter=%s",
+		repoID, url.QueryEscape("heads/"+prefix)))
+	return getAllPages[GitRef](ctx, c, rawURL, 0)
+}
+
+// commitsBatchSize is the number of commits requested per commitsbatch call.
+const commitsBatchSize = 100
+
+// GetCommits returns the commits with the given IDs, including their authors.
+// IDs are fetched in batches; commits that do not exist are left out.
+func (c *Client) GetCommits(ctx context.Context, project, repoID string, ids []string) ([]GitCommitRef, error) {
+	var commits []GitCommitRef
+	for start := 0; start < len(ids); start += commitsBatchSize {
+		batch := ids[start:min(start+commitsBatchSize, len(ids))]
+		rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/commitsbatch?$top=%d", repoID, len(batch)))
+		body := map[string][]string{"ids": batch}
+		resp, err := c.doRaw(ctx, http.MethodPost, rawURL, "application/json", body)
+		if err != nil {
+			return nil, err
+		}
+		var result listPage[GitCommitRef]
+		if err := decodeOrClose(resp, &result); err != nil {
+			return nil, err
+		}
+		commits = append(commits, result.Value...)
+	}
+	return commits, nil
+}
+
+// GetItemContent returns the raw content of the file at path as of the given
+// commit.
+func (c *Client) GetItemContent(ctx context.Context, project, repoID, path, commitID string) ([]byte, error) {
+	q := url.Values{}
+	q.Set("path", path)
+	q.Set("versionDescriptor.version", commitID)
+	q.Set("versionDescriptor.versionType", "commit")
+	q.Set("$format", "octetStream")
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/items?%s", repoID, q.Encode()))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, newError(resp.StatusCode, body)
+	}
+	return body, nil
+}
@@ -0,0 +1,31 @@
+package api
+
+import "context"
+
+// TeamIteration is a sprint selected by a team in its settings.
+type TeamIteration struct {
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	Path       string              `json:"path"`
+	Attributes IterationAttributes `json:"attributes"`
+	URL        string              `json:"url"`
+}
+
+// IterationAttributes holds the dates of an iteration. TimeFrame is "past",
+// "current", or "future".
+type IterationAttributes struct {
+	StartDate  string `json:"startDate,omitempty"`
+	FinishDate string `json:"finishDate,omitempty"`
+	TimeFrame  string `json:"timeFrame"`
+}
+
+// ListIterations returns the iterations a team has selected. An empty team
+// means the project's default team; current limits the result to the
+// iteration in progress.
+func (c *Client) ListIterations(ctx context.Context, project, team string, current bool) ([]TeamIteration, error) {
+	rawURL := c.TeamURL(project, team, "work/teamsettings/iterations")
+	if current {
+		rawURL += "?$timeframe=current"
+	}
+	return getAllPages[TeamIteration](ctx, c, rawURL, 0)
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CommentThread is a discussion thread on a pull request.
+type CommentThread struct {
+	ID              int            `json:"id"`
+	Status          string         `json:"status,omitempty"`
+	ThreadContext   *ThreadContext `json:"threadContext,omitempty"`
+	Comments        []Comment      `json:"comments"`
+	PublishedDate   string         `json:"publishedDate,omitempty"`
+	LastUpdatedDate string         `json:"lastUpdatedDate,omitempty"`
+	IsDeleted       bool           `json:"isDeleted,omitempty"`
+}
+
+// ThreadContext anchors a thread to a file and line range in the PR's source.
+type ThreadContext struct {
+	FilePath       string        `json:"filePath"`
+	RightFileStart *FilePosition `json:"rightFileStart,omitempty"`
+	RightFileEnd   *FilePosition `json:"rightFileEnd,omitempty"`
+}
+
+// FilePosition is a 1-based line and character offset within a file.
+type FilePosition struct {
+	Line   int `json:"line"`
+	Offset int `json:"offset"`
+}
+
+// Comment is a single comment in a thread.
+type Comment struct {
+	ID              int          `json:"id,omitempty"`
+	ParentCommentID int          `json:"parentCommentId,omitempty"`
+	Author          *IdentityRef `json:"author,omitempty"`
+	Content         string       `json:"content"`
+	CommentType     string       `json:"commentType,omitempty"`
+	PublishedDate   string       `json:"publishedDate,omitempty"`
+}
+
+// CreatePullRequestThread starts a new comment thread on a pull request.
+// If threadCtx is nil the thread is a general PR comment.
+func (c *Client) CreatePullRequestThread(ctx context.Context, project, repoID string, prID int, content string, threadCtx *ThreadContext) (*CommentThread, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests/%d/threads", repoID, prID))
+	body := CommentThread{
+		Status:        "active",
+		ThreadContext: threadCtx,
+		Comments: []Comment{
+			{Content: content, CommentType: "text"},
+		},
+	}
+	resp, err := c.doRaw(ctx, http.MethodPost, rawURL, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	var thread CommentThread
+	if err := decodeOrClose(resp, &thread); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
+type commentThreadList struct {
+	Count int             `json:"count"`
+	Value []CommentThread `json:"value"`
+}
+
+// ListPullRequestThreads returns all comment threads on a pull request.
+func (c *Client) ListPullRequestThreads(ctx context.Context, project, repoID string, prID int) ([]CommentThread, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("git/repositories/%s/pullrequests/%d/threads", repoID, prID))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result commentThreadList
+	if err := decodeOrClose(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
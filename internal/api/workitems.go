@@ -1,15 +1,47 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 )
 
 // WiqlResult is the response from a WIQL query.
+// Flat queries populate WorkItems; tree and one-hop queries populate WorkItemRelations.
 type WiqlResult struct {
-	WorkItems []WiqlWorkItemRef `json:"workItems"`
+	QueryType         string             `json:"queryType"`
+	WorkItems         []WiqlWorkItemRef  `json:"workItems"`
+	WorkItemRelations []WiqlWorkItemLink `json:"workItemRelations"`
+}
+
+// WiqlWorkItemLink is a source/target pair returned by tree and one-hop queries.
+type WiqlWorkItemLink struct {
+	Rel    string           `json:"rel"`
+	Source *WiqlWorkItemRef `json:"source"`
+	Target *WiqlWorkItemRef `json:"target"`
+}
+
+// IDs returns the distinct work item IDs in the result, in result order.
+func (r *WiqlResult) IDs() []int {
+	seen := map[int]bool{}
+	var ids []int
+	add := func(ref *WiqlWorkItemRef) {
+		if ref != nil && !seen[ref.ID] {
+			seen[ref.ID] = true
+			ids = append(ids, ref.ID)
+		}
+	}
+	for i := range r.WorkItems {
+		add(&r.WorkItems[i])
+	}
+	for _, l := range r.WorkItemRelations {
+		add(l.Source)
+		add(l.Target)
+	}
+	return ids
 }
 
 // WiqlWorkItemRef is a lightweight reference returned by WIQL.
@@ -20,10 +52,42 @@ type WiqlWorkItemRef struct {
 
 // WorkItem represents a work item from the API.
 type WorkItem struct {
-	ID     int                    `json:"id"`
-	Rev    int                    `json:"rev"`
-	Fields map[string]interface{} `json:"fields"`
-	URL    string                 `json:"url"`
+	ID        int                    `json:"id"`
+	Rev       int                    `json:"rev"`
+	Fields    map[string]interface{} `json:"fields"`
+	Relations []WorkItemRelation     `json:"relations,omitempty"`
+	URL       string                 `json:"url"`
+	WebURL    string                 `json:"webUrl,omitempty"` // set by callers via WorkItemWebURL
+}
+
+// Relation types used for work item links.
+const (
+	RelHierarchyForward = "System.LinkTypes.Hierarchy-Forward" // child
+	RelHierarchyReverse = "System.LinkTypes.Hierarchy-Reverse" // parent
+	RelRelated          = "System.LinkTypes.Related"
+	RelArtifactLink     = "ArtifactLink"
+	RelAttachedFile     = "AttachedFile"
+)
+
+// WorkItemRelation is a link from a work item to another work item or artifact.
+type WorkItemRelation struct {
+	Rel        string                 `json:"rel"`
+	URL        string                 `json:"url"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// TargetID returns the ID of the linked work item, parsed from the relation URL.
+// It returns false if the relation does not point at a work item.
+func (r WorkItemRelation) TargetID() (int, bool) {
+	i := strings.LastIndex(r.URL, "/workItems/")
+	if i < 0 {
+		return 0, false
+	}
+	id, err := strconv.Atoi(r.URL[i+len("/workItems/"):])
+	if err != nil {
+		return 0, false
+	}
+	return id, true
 }
 
 // WorkItemList is the response when fetching multiple work items.
@@ -39,13 +103,40 @@ type PatchField struct {
 	Value interface{} `json:"value"`
 }
 
+// WorkItemStateColor is a workflow state of a work item type.
+// Category is one of Proposed, InProgress, Resolved, Completed, or Removed.
+type WorkItemStateColor struct {
+	Name     string `json:"name"`
+	Color    string `json:"color"`
+	Category string `json:"category"`
+}
+
+type workItemStateList struct {
+	Count int                  `json:"count"`
+	Value []WorkItemStateColor `json:"value"`
+}
+
+// WorkItemWebURL returns the browser URL for a work item.
+func (c *Client) WorkItemWebURL(project string, id int) string {
+	return c.WebURL(project, fmt.Sprintf("_workitems/edit/%d", id))
+}
+
+// WorkItemAPIURL returns the REST URL of a work item, as used in relation links.
+func (c *Client) WorkItemAPIURL(id int) string {
+	return fmt.Sprintf("%s/wit/workItems/%d", c.BaseURL, id)
+}
+
 // QueryByWiql runs a WIQL query and returns matching work item references.
-// The top parameter limits the number of results returned by the server.
-func (c *Client) QueryByWiql(project, wiql string, top int) (*WiqlResult, error) {
+// The top parameter, if positive, is sent as $top so the server stops after
+// that many results; it should match any TOP clause in the query text.
+func (c *Client) QueryByWiql(ctx context.Context, project, wiql string, top int) (*WiqlResult, error) {
 	body := map[string]string{"query": wiql}
-	url := c.ProjectURL(project, fmt.Sprintf("wit/wiql?$top=%d", top))
+	url := c.ProjectURL(project, "wit/wiql")
+	if top > 0 {
+		url += fmt.Sprintf("?$top=%d", top)
+	}
 
-	resp, err := c.doRaw(http.MethodPost, url, "application/json", body)
+	resp, err := c.doRaw(ctx, http.MethodPost, url, "application/json", body)
 	if err != nil {
 		return nil, err
 	}
@@ -56,38 +147,89 @@ func (c *Client) QueryByWiql(project, wiql string, top int) (*WiqlResult, error)
 	return &result, nil
 }
 
-// GetWorkItem retrieves a single work item by ID.
-func (c *Client) GetWorkItem(project string, id int) (*WorkItem, error) {
+// GetWorkItem retrieves a single work item by ID. A non-empty expand
+// (e.g. "relations" or "all") is passed through as $expand.
+func (c *Client) GetWorkItem(ctx context.Context, project string, id int, expand string) (*WorkItem, error) {
 	path := fmt.Sprintf("wit/workitems/%d", id)
+	if expand != "" {
+		path += "?$expand=" + expand
+	}
 	var wi WorkItem
-	if err := c.Get(path, &wi); err != nil {
+	if err := c.Get(ctx, path, &wi); err != nil {
 		return nil, err
 	}
 	return &wi, nil
 }
 
 // GetWorkItems retrieves multiple work items by IDs in a single batch call.
-func (c *Client) GetWorkItems(project string, ids []int) ([]WorkItem, error) {
+func (c *Client) GetWorkItems(ctx context.Context, project string, ids []int) ([]WorkItem, error) {
+	return c.GetWorkItemsWithOptions(ctx, project, ids, WorkItemsOptions{})
+}
+
+// WorkItemsOptions controls what the batch work item endpoint returns.
+// The API does not allow Fields and Expand to be combined.
+type WorkItemsOptions struct {
+	Fields []string // only return these fields (e.g. "System.Title")
+	Expand string   // $expand value, e.g. "relations"
+}
+
+// maxBatchIDs is the most IDs the batch work item endpoint accepts per request.
+const maxBatchIDs = 200
+
+// GetWorkItemsWithOptions is like GetWorkItems but limits the returned fields
+// or expands additional data according to opts. IDs are requested in chunks of
+// 200 and the results are returned in the order of ids.
+func (c *Client) GetWorkItemsWithOptions(ctx context.Context, project string, ids []int, opts WorkItemsOptions) ([]WorkItem, error) {
 	if len(ids) == 0 {
 		return nil, nil
 	}
+
+	byID := make(map[int]WorkItem, len(ids))
+	for start := 0; start < len(ids); start += maxBatchIDs {
+		end := min(start+maxBatchIDs, len(ids))
+		chunk, err := c.getWorkItemsBatch(ctx, ids[start:end], opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, wi := range chunk {
+			byID[wi.ID] = wi
+		}
+	}
+
+	items := make([]WorkItem, 0, len(byID))
+	for _, id := range ids {
+		if wi, ok := byID[id]; ok {
+			items = append(items, wi)
+		}
+	}
+	return items, nil
+}
+
+// getWorkItemsBatch fetches up to maxBatchIDs work items in one request.
+func (c *Client) getWorkItemsBatch(ctx context.Context, ids []int, opts WorkItemsOptions) ([]WorkItem, error) {
 	strs := make([]string, len(ids))
 	for i, id := range ids {
 		strs[i] = strconv.Itoa(id)
 	}
 	path := fmt.Sprintf("wit/workitems?ids=%s", strings.Join(strs, ","))
+	if len(opts.Fields) > 0 {
+		path += "&fields=" + url.QueryEscape(strings.Join(opts.Fields, ","))
+	}
+	if opts.Expand != "" {
+		path += "&$expand=" + opts.Expand
+	}
 	var result WorkItemList
-	if err := c.Get(path, &result); err != nil {
+	if err := c.Get(ctx, path, &result); err != nil {
 		return nil, err
 	}
 	return result.Value, nil
 }
 
 // CreateWorkItem creates a new work item using JSON Patch.
-func (c *Client) CreateWorkItem(project, workItemType string, fields []PatchField) (*WorkItem, error) {
+func (c *Client) CreateWorkItem(ctx context.Context, project, workItemType string, fields []PatchField) (*WorkItem, error) {
 	url := c.ProjectURL(project, fmt.Sprintf("wit/workitems/$%s", workItemType))
 
-	resp, err := c.doRaw(http.MethodPost, url, "application/json-patch+json", fields)
+	resp, err := c.doRaw(ctx, http.MethodPost, url, "application/json-patch+json", fields)
 	if err != nil {
 		return nil, err
 	}
@@ -99,10 +241,39 @@ func (c *Client) CreateWorkItem(project, workItemType string, fields []PatchFiel
 }
 
 // UpdateWorkItem updates an existing work item using JSON Patch.
-func (c *Client) UpdateWorkItem(project string, id int, fields []PatchField) (*WorkItem, error) {
+func (c *Client) UpdateWorkItem(ctx context.Context, project string, id int, fields []PatchField) (*WorkItem, error) {
+	rawURL := fmt.Sprintf("%s/wit/workitems/%d", c.BaseURL, id)
+
+	resp, err := c.doRaw(ctx, http.MethodPatch, rawURL, "application/json-patch+json", fields)
+	if err != nil {
+		return nil, err
+	}
+	var wi WorkItem
+	if err := decodeOrClose(resp, &wi); err != nil {
+		return nil, err
+	}
+	return &wi, nil
+}
+
+// MoveWorkItem moves a work item to another project. System.TeamProject,
+// System.AreaPath and System.IterationPath must change together, so they are
+// sent in one patch against the org-level URL; empty paths default to the
+// destination project's root area and iteration.
+func (c *Client) MoveWorkItem(ctx context.Context, id int, toProject, areaPath, iterationPath string) (*WorkItem, error) {
+	if areaPath == "" {
+		areaPath = toProject
+	}
+	if iterationPath == "" {
+		iterationPath = toProject
+	}
+	fields := []PatchField{
+		{Op: "add", Path: "/fields/System.TeamProject", Value: toProject},
+		{Op: "add", Path: "/fields/System.AreaPath", Value: areaPath},
+		{Op: "add", Path: "/fields/System.IterationPath", Value: iterationPath},
+	}
 	rawURL := fmt.Sprintf("%s/wit/workitems/%d", c.BaseURL, id)
 
-	resp, err := c.doRaw(http.MethodPatch, rawURL, "application/json-patch+json", fields)
+	resp, err := c.doRaw(ctx, http.MethodPatch, rawURL, "application/json-patch+json", fields)
 	if err != nil {
 		return nil, err
 	}
@@ -112,3 +283,107 @@ func (c *Client) UpdateWorkItem(project string, id int, fields []PatchField) (*W
 	}
 	return &wi, nil
 }
+
+// WorkItemType is a work item type defined by a project's process.
+type WorkItemType struct {
+	Name          string `json:"name"`
+	ReferenceName string `json:"referenceName"`
+	Description   string `json:"description"`
+	IsDisabled    bool   `json:"isDisabled"`
+}
+
+// GetWorkItemTypes returns the work item types of a project. Results are
+// cached per project for the lifetime of the client.
+func (c *Client) GetWorkItemTypes(ctx context.Context, project string) ([]WorkItemType, error) {
+	c.cacheMu.Lock()
+	cached, ok := c.workItemTypes[project]
+	c.cacheMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	types, err := getAllPages[WorkItemType](ctx, c, c.ProjectURL(project, "wit/workitemtypes"), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cacheMu.Lock()
+	if c.workItemTypes == nil {
+		c.workItemTypes = map[string][]WorkItemType{}
+	}
+	c.workItemTypes[project] = types
+	c.cacheMu.Unlock()
+	return types, nil
+}
+
+// GetWorkItemTypeStates returns the workflow states defined for a work item type.
+func (c *Client) GetWorkItemTypeStates(ctx context.Context, project, workItemType string) ([]WorkItemStateColor, error) {
+	rawURL := c.ProjectURL(project, fmt.Sprintf("wit/workitemtypes/%s/states", url.PathEscape(workItemType)))
+	resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result workItemStateList
+	if err := decodeOrClose(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// AddWorkItemRelation links a work item to another work item or artifact.
+// rel is the link type (e.g. RelHierarchyReverse to add a parent) and targetURL
+// is the REST URL of the target.
+func (c *Client) AddWorkItemRelation(ctx context.Context, project string, id int, rel, targetURL string) (*WorkItem, error) {
+	fields := []PatchField{
+		{Op: "add", Path: "/relations/-", Value: WorkItemRelation{Rel: rel, URL: targetURL}},
+	}
+	return c.UpdateWorkItem(ctx, project, id, fields)
+}
+
+// LinkWorkItemToPR adds an artifact link from a work item to a pull request,
+// so the work item shows up under the PR's linked work items.
+func (c *Client) LinkWorkItemToPR(ctx context.Context, project string, workItemID int, projectID, repoID string, prID int) (*WorkItem, error) {
+	rel := WorkItemRelation{
+		Rel:        RelArtifactLink,
+		URL:        PullRequestArtifactURL(projectID, repoID, prID),
+		Attributes: map[string]interface{}{"name": "Pull Request"},
+	}
+	fields := []PatchField{
+		{Op: "add", Path: "/relations/-", Value: rel},
+	}
+	return c.UpdateWorkItem(ctx, project, workItemID, fields)
+}
+
+// RemoveWorkItemRelation removes the relation at the given index of the work
+// item's relations array.
+func (c *Client) RemoveWorkItemRelation(ctx context.Context, project string, id, index int) (*WorkItem, error) {
+	fields := []PatchField{
+		{Op: "remove", Path: fmt.Sprintf("/relations/%d", index)},
+	}
+	return c.UpdateWorkItem(ctx, project, id, fields)
+}
+
+// revisionsPageSize is the number of revisions requested per page; the
+// service caps a single response at 200.
+const revisionsPageSize = 200
+
+// GetWorkItemRevisions returns every revision of a work item, oldest first.
+// Results are paged until a short page is returned.
+func (c *Client) GetWorkItemRevisions(ctx context.Context, project string, id int) ([]WorkItem, error) {
+	var revs []WorkItem
+	for skip := 0; ; skip += revisionsPageSize {
+		rawURL := c.ProjectURL(project, fmt.Sprintf("wit/workItems/%d/revisions?$top=%d&$skip=%d", id, revisionsPageSize, skip))
+		resp, err := c.doRaw(ctx, http.MethodGet, rawURL, "application/json", nil)
+		if err != nil {
+			return nil, err
+		}
+		var page WorkItemList
+		if err := decodeOrClose(resp, &page); err != nil {
+			return nil, err
+		}
+		revs = append(revs, page.Value...)
+		if len(page.Value) < revisionsPageSize {
+			return revs, nil
+		}
+	}
+}
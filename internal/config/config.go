@@ -15,9 +15,52 @@ const (
 
 // Config holds ado CLI user configuration.
 type Config struct {
-	Organization string `json:"organization"`  // Azure DevOps org name or URL
-	Project      string `json:"project"`       // Default project name
-	OutputFormat string `json:"output_format"` // "table", "json", or "plain"
+	Organization string `json:"organization"`   // Azure DevOps org name or URL
+	Project      string `json:"project"`        // Default project name
+	OutputFormat string `json:"output_format"`  // "table", "json", "plain", or "csv"
+	Repo         string `json:"repo,omitempty"` // Default repository for pr commands
+
+	AutoLabelCLIPRs bool   `json:"auto_label_cli_prs,omitempty"` // Label PRs created by ado
+	APIVersion      string `json:"api_version,omitempty"`        // REST api-version (default 7.1)
+
+	Profiles      map[string]Profile `json:"profiles,omitempty"`       // Named organization profiles
+	ActiveProfile string             `json:"active_profile,omitempty"` // Profile in use, if any
+}
+
+// Profile is a named organization (and optional default project) that can be
+// switched to with 'ado config profile use'.
+type Profile struct {
+	Organization string `json:"organization"`
+	Project      string `json:"project,omitempty"`
+}
+
+// Active returns the active profile, if one is set and exists.
+func (c *Config) Active() (Profile, bool) {
+	if c.ActiveProfile == "" {
+		return Profile{}, false
+	}
+	p, ok := c.Profiles[c.ActiveProfile]
+	return p, ok
+}
+
+// Validate reports the first invalid value in the config.
+func (c *Config) Validate() error {
+	switch c.OutputFormat {
+	case "", "table", "json", "plain", "csv":
+	default:
+		return fmt.Errorf("invalid output_format %q (must be table, json, plain, or csv)", c.OutputFormat)
+	}
+	for name, p := range c.Profiles {
+		if p.Organization == "" {
+			return fmt.Errorf("profile %q has no organization", name)
+		}
+	}
+	if c.ActiveProfile != "" {
+		if _, ok := c.Profiles[c.ActiveProfile]; !ok {
+			return fmt.Errorf("active_profile %q does not exist", c.ActiveProfile)
+		}
+	}
+	return nil
 }
 
 // Path returns the full path to the config file.
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const credentialsFile = "credentials"
+
+// CredentialsPath returns the path of the file-based PAT store, used when no
+// OS keyring is available. The file is plain JSON readable only by its owner.
+func CredentialsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("getting home directory: %w", err)
+	}
+	return filepath.Join(home, configDir, credentialsFile), nil
+}
+
+// loadCredentials reads the account -> PAT map. A missing file is empty.
+func loadCredentials() (map[string]string, error) {
+	p, err := CredentialsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("reading credentials file: %w", err)
+	}
+	creds := map[string]string{}
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("parsing credentials file: %w", err)
+	}
+	return creds, nil
+}
+
+// saveCredentials writes the account -> PAT map with 0600 permissions.
+func saveCredentials(creds map[string]string) error {
+	p, err := CredentialsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling credentials: %w", err)
+	}
+	data = append(data, '\n')
+	if err := os.WriteFile(p, data, 0600); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file; tighten it regardless.
+	return os.Chmod(p, 0600)
+}
+
+// GetCredential returns the PAT stored for account, or "" if there is none.
+func GetCredential(account string) (string, error) {
+	creds, err := loadCredentials()
+	if err != nil {
+		return "", err
+	}
+	return creds[account], nil
+}
+
+// SetCredential stores the PAT for account.
+func SetCredential(account, pat string) error {
+	creds, err := loadCredentials()
+	if err != nil {
+		return err
+	}
+	creds[account] = pat
+	return saveCredentials(creds)
+}
+
+// DeleteCredential removes the PAT for account. It reports whether one existed.
+func DeleteCredential(account string) (bool, error) {
+	creds, err := loadCredentials()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := creds[account]; !ok {
+		return false, nil
+	}
+	delete(creds, account)
+	return true, saveCredentials(creds)
+}